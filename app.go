@@ -3,7 +3,9 @@
 package autofiber
 
 import (
+	"errors"
 	"net/http"
+	"reflect"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -44,35 +46,154 @@ func WithErrorHandler(fn func(*fiber.Ctx, error) error) AutoFiberOption {
 //	}))
 func WithValidatorSetup(fn func(*validator.Validate)) AutoFiberOption {
 	return func(af *AutoFiber) {
+		af.validatorSetups = append(af.validatorSetups, fn)
 		fn(af.validator)
 	}
 }
 
+// WithDefaultValidator also registers the app's custom validation on the package default validator
+// returned by GetValidator, which ValidateStruct and AutoParseRequest(schema, nil) use, so domain
+// code sees the app's custom tags. New replays the WithValidatorSetup functions on it, in any option
+// order, and RegisterValidator registers there as well. The package validator keeps naming fields
+// by Go name and gets no translations; the app's own validator is unchanged.
+//
+// Example:
+//
+//	app := autofiber.New(fiber.Config{},
+//	    autofiber.WithValidatorSetup(registerDomainTags),
+//	    autofiber.WithDefaultValidator(),
+//	)
+func WithDefaultValidator() AutoFiberOption {
+	return func(af *AutoFiber) {
+		af.defaultValidator = true
+	}
+}
+
+// WithResponseValidation sets the app-wide response validation mode for routes with a response schema.
+// Use ReportResponseValidation or SampleResponseValidation to keep validation enabled in production
+// without failing requests. Routes can override it with WithRouteResponseValidation.
+//
+// Example:
+//
+//	app := autofiber.New(fiber.Config{},
+//	    autofiber.WithResponseValidation(autofiber.SampleResponseValidation(0.05)),
+//	)
+func WithResponseValidation(mode ResponseValidationMode) AutoFiberOption {
+	return func(af *AutoFiber) {
+		af.responseValidation = mode
+	}
+}
+
+// WithResponseValidationReporter sets the callback invoked when a response fails validation
+// in report or sample mode. Without it, failures are logged through Fiber's logger.
+func WithResponseValidationReporter(fn func(*fiber.Ctx, *ValidationResponseError)) AutoFiberOption {
+	return func(af *AutoFiber) {
+		af.responseReporter = fn
+	}
+}
+
 // AutoFiber is the main application struct for building APIs with automatic parsing, validation, and documentation.
 type AutoFiber struct {
-	App           *fiber.App
-	docsGenerator *DocsGenerator
-	validator     *validator.Validate
-	errorHandler  func(*fiber.Ctx, error) error
+	App                *fiber.App
+	docsGenerator      *DocsGenerator
+	validator          *validator.Validate
+	errorHandler       func(*fiber.Ctx, error) error
+	responseValidation ResponseValidationMode
+	responseReporter   func(*fiber.Ctx, *ValidationResponseError)
+	translations       *translations
+	asyncValidators    *asyncValidators
+	problemDetails     bool
+	errorCatalog       *errorCatalog
+	logger             Logger
+	recoverPanics      bool
+	encoders           *encoders
+	envelope           *envelopeConfig
+
+	// defaultValidator is set by WithDefaultValidator, for which New replays validatorSetups,
+	// the WithValidatorSetup functions, on the package default validator.
+	defaultValidator bool
+	validatorSetups  []func(*validator.Validate)
 }
 
 // New creates a new AutoFiber application instance with custom options.
 func New(config fiber.Config, options ...AutoFiberOption) *AutoFiber {
+	v := validator.New()
 	af := &AutoFiber{
-		App:           fiber.New(config),
-		docsGenerator: NewDocsGenerator(),
-		validator:     validator.New(),
+		App:             fiber.New(config),
+		docsGenerator:   NewDocsGenerator(),
+		validator:       v,
+		translations:    newTranslations(v),
+		asyncValidators: newAsyncValidators(),
+		errorCatalog:    newErrorCatalog(),
+		logger:          fiberLogger{},
 	}
+	af.encoders = newEncoders(af.App.Config().JSONEncoder)
+	af.docsGenerator.encoders = af.encoders
 	for _, option := range options {
 		option(af)
 	}
+	if af.defaultValidator {
+		for _, setup := range af.validatorSetups {
+			setup(validate)
+		}
+	}
 	return af
 }
 
-// RegisterValidator registers a custom validation function on the instance's validator.
-// Use this after New() to add validations that should apply to all routes on this instance.
+// RegisterValidator registers a custom validation function on the instance's validator, and on
+// the package default validator with WithDefaultValidator. Use this after New() to add
+// validations that should apply to all routes on this instance.
 func (af *AutoFiber) RegisterValidator(tag string, fn validator.Func) error {
-	return af.validator.RegisterValidation(tag, fn)
+	if err := af.validator.RegisterValidation(tag, fn); err != nil {
+		return err
+	}
+	if af.defaultValidator {
+		return validate.RegisterValidation(tag, fn)
+	}
+	return nil
+}
+
+// ValidateStruct validates v (a struct or pointer to struct) with the instance validator, so custom
+// tags registered through WithValidatorSetup or RegisterValidator apply. Failures are returned as a
+// *ValidationRequestError whose details use json field paths and English messages, the same shape
+// the HTTP layer produces.
+//
+// Example:
+//
+//	if err := app.ValidateStruct(order); err != nil {
+//	    var verr *autofiber.ValidationRequestError
+//	    if errors.As(err, &verr) {
+//	        // verr.Details[0].Field == "items[0].sku"
+//	    }
+//	}
+func (af *AutoFiber) ValidateStruct(v interface{}) error {
+	err := af.validator.Struct(v)
+	if err == nil {
+		return nil
+	}
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
+
+	root := derefType(reflect.TypeOf(v))
+	trans := af.translations.defaultTranslator()
+	details := make([]FieldErrorDetail, 0, len(validationErrs))
+	for _, verr := range validationErrs {
+		path := namespaceToPath(root, verr.StructNamespace())
+		details = append(details, FieldErrorDetail{
+			Field:   formatFieldPath(path),
+			Message: af.translations.message(trans, verr),
+			Tag:     verr.Tag(),
+			Pointer: jsonPointer(path),
+		})
+	}
+	return &ValidationRequestError{
+		Message: "Validation failed",
+		Code:    CodeValidationFailed,
+		Details: details,
+		Status:  fiber.StatusUnprocessableEntity,
+	}
 }
 
 // Group creates a new route group with the given prefix.
@@ -100,11 +221,22 @@ func (af *AutoFiber) Test(req *http.Request, msTimeout ...int) (*http.Response,
 	return af.App.Test(req, msTimeout...)
 }
 
-// handleError routes a validation/parse error through the custom error handler when one is set,
-// or returns it directly for fiber's error handler otherwise.
+// handleError routes a validation/parse error or HTTPError through the custom error handler when one
+// is set. Otherwise, and for fiber errors such as the missing-Authorization 401, it writes a problem
+// details response when WithProblemDetails is enabled, an enveloped error when WithEnvelope is,
+// writes an HTTPError with its status, or returns the error for fiber's error handler.
 func (af *AutoFiber) handleError(c *fiber.Ctx, err error) error {
-	if af.errorHandler != nil {
+	if _, isFiberErr := err.(*fiber.Error); af.errorHandler != nil && !isFiberErr {
 		return af.errorHandler(c, err)
 	}
+	if af.problemDetails {
+		return writeProblem(c, err)
+	}
+	if af.envelope != nil {
+		return af.envelope.writeError(c, err)
+	}
+	if httpErr, ok := err.(*HTTPError); ok {
+		return c.Status(httpErr.Status).JSON(httpErr)
+	}
 	return err
 }
@@ -182,3 +182,107 @@ func TestHandleError_WithCustomHandler(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	assert.True(t, customCalled)
 }
+
+func TestWithResponseValidation_Modes(t *testing.T) {
+	type Resp struct {
+		ID int `json:"id" validate:"required"`
+	}
+	invalid := func(c *fiber.Ctx) (interface{}, error) {
+		return Resp{}, nil
+	}
+
+	// Enforce (default) turns the invalid response into an error.
+	app := newTestApp()
+	app.Get("/r", invalid, autofiber.WithResponseSchema(Resp{}))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/r", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+	// Report sends the response and passes the failure to the reporter.
+	var reported *autofiber.ValidationResponseError
+	app = autofiber.New(fiber.Config{},
+		autofiber.WithResponseValidation(autofiber.ReportResponseValidation()),
+		autofiber.WithResponseValidationReporter(func(c *fiber.Ctx, err *autofiber.ValidationResponseError) {
+			reported = err
+		}),
+	)
+	app.Get("/r", invalid, autofiber.WithResponseSchema(Resp{}))
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/r", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	if assert.NotNil(t, reported) {
+		assert.Equal(t, "response.id", reported.Details[0].Field)
+	}
+
+	// A route can opt back into enforcement.
+	app.Get("/strict", invalid,
+		autofiber.WithResponseSchema(Resp{}),
+		autofiber.WithRouteResponseValidation(autofiber.EnforceResponseValidation()),
+	)
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/strict", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+}
+
+func TestWithResponseValidation_Sample(t *testing.T) {
+	type Resp struct {
+		ID int `json:"id" validate:"required"`
+	}
+
+	reports := 0
+	app := autofiber.New(fiber.Config{},
+		autofiber.WithResponseValidation(autofiber.SampleResponseValidation(0)),
+		autofiber.WithResponseValidationReporter(func(c *fiber.Ctx, err *autofiber.ValidationResponseError) {
+			reports++
+		}),
+	)
+	app.Get("/never", func(c *fiber.Ctx) (interface{}, error) {
+		return Resp{}, nil
+	}, autofiber.WithResponseSchema(Resp{}))
+	app.Get("/always", func(c *fiber.Ctx) (interface{}, error) {
+		return Resp{}, nil
+	}, autofiber.WithResponseSchema(Resp{}), autofiber.WithRouteResponseValidation(autofiber.SampleResponseValidation(1)))
+
+	for i := 0; i < 5; i++ {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/never", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	assert.Equal(t, 0, reports)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/always", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 1, reports)
+}
+
+func TestAutoFiber_ValidateStruct(t *testing.T) {
+	app := autofiber.New(fiber.Config{}, autofiber.WithValidatorSetup(func(v *validator.Validate) {
+		_ = v.RegisterValidation("sku", func(fl validator.FieldLevel) bool {
+			return strings.HasPrefix(fl.Field().String(), "SKU-")
+		})
+	}))
+
+	type Line struct {
+		SKU string `json:"sku" validate:"sku"`
+	}
+	type Order struct {
+		Email string `json:"email" validate:"required,email"`
+		Lines []Line `json:"lines" validate:"dive"`
+	}
+
+	assert.NoError(t, app.ValidateStruct(&Order{Email: "a@b.co", Lines: []Line{{SKU: "SKU-1"}}}))
+
+	err := app.ValidateStruct(Order{Lines: []Line{{SKU: "SKU-1"}, {SKU: "x"}}})
+	var verr *autofiber.ValidationRequestError
+	if assert.ErrorAs(t, err, &verr) {
+		assert.Equal(t, "Validation failed", verr.Message)
+		assert.Equal(t, []autofiber.FieldErrorDetail{
+			{Field: "email", Message: "email is a required field", Tag: "required", Pointer: "/email"},
+			{Field: "lines[1].sku", Message: "sku failed the 'sku' validation", Tag: "sku", Pointer: "/lines/1/sku"},
+		}, verr.Details)
+	}
+
+	// The package-level validator does not know instance tags.
+	assert.Panics(t, func() { _ = autofiber.ValidateStruct(&Line{SKU: "x"}) })
+}
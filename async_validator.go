@@ -0,0 +1,279 @@
+// Package autofiber provides context-aware asynchronous validators for checks that need external state.
+package autofiber
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// defaultAsyncValidationTimeout bounds all asynchronous checks of a single request.
+const defaultAsyncValidationTimeout = 5 * time.Second
+
+// AsyncValidatorFunc checks a field value against external state, such as "email not already taken".
+// ctx carries the request's user context and the async validation deadline; string keys not found
+// there are looked up in a copy of the request's Locals taken before validation, so a DB handle set
+// by middleware is available as ctx.Value("db"). param is the tag parameter ("org" for
+// `validate:"exists=org"`). Return false to fail the field, or an error when the check itself could
+// not complete.
+//
+// Validators run concurrently and are not given the *fiber.Ctx: checks still running at the
+// deadline outlive the request, after which Fiber reuses it.
+type AsyncValidatorFunc func(ctx context.Context, value interface{}, param string) (bool, error)
+
+// WithAsyncValidationTimeout sets the deadline for all asynchronous validators of a request (default 5s).
+// Requests whose checks have not finished by then fail with 504 and the validation_timeout code.
+func WithAsyncValidationTimeout(d time.Duration) AutoFiberOption {
+	return func(af *AutoFiber) {
+		af.asyncValidators.timeout = d
+	}
+}
+
+// RegisterAsyncValidator registers a context-aware validator usable in validate tags like any other
+// tag. Async validators run concurrently after synchronous validation has passed; failing fields
+// are reported as FieldErrorDetail entries alongside ordinary validation errors. Messages come from
+// RegisterTranslation for the tag, or a generic sentence.
+//
+// Example:
+//
+//	app.RegisterAsyncValidator("unique_email", func(ctx context.Context, value interface{}, _ string) (bool, error) {
+//	    db := ctx.Value("db").(*sql.DB)
+//	    var exists bool
+//	    err := db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)", value).Scan(&exists)
+//	    return !exists, err
+//	})
+//
+//	type SignupRequest struct {
+//	    Email string `json:"email" validate:"required,email,unique_email"`
+//	}
+func (af *AutoFiber) RegisterAsyncValidator(tag string, fn AsyncValidatorFunc) error {
+	// Register a no-op synchronous validation so the tag is accepted by the validator.
+	if err := af.RegisterValidator(tag, func(validator.FieldLevel) bool { return true }); err != nil {
+		return err
+	}
+	af.asyncValidators.register(tag, fn)
+	return nil
+}
+
+// asyncValidators holds the registered async validators and the per-schema check plans.
+type asyncValidators struct {
+	mu      sync.RWMutex
+	funcs   map[string]AsyncValidatorFunc
+	plans   sync.Map // map[reflect.Type][]asyncCheck
+	timeout time.Duration
+}
+
+// asyncCheck is one async tag on one field of a request schema.
+type asyncCheck struct {
+	index     []int  // field index path from the schema root
+	namespace string // struct namespace, e.g. "SignupRequest.Org.ID"
+	name      string // wire name (parse key, json name or Go name), used in messages
+	tag       string
+	param     string
+	omitEmpty bool
+}
+
+// newAsyncValidators creates an empty async validator registry.
+func newAsyncValidators() *asyncValidators {
+	return &asyncValidators{
+		funcs:   make(map[string]AsyncValidatorFunc),
+		timeout: defaultAsyncValidationTimeout,
+	}
+}
+
+// register adds fn under tag and drops cached plans, which capture the registered tags.
+func (a *asyncValidators) register(tag string, fn AsyncValidatorFunc) {
+	a.mu.Lock()
+	a.funcs[tag] = fn
+	a.mu.Unlock()
+	a.plans.Range(func(key, _ interface{}) bool {
+		a.plans.Delete(key)
+		return true
+	})
+}
+
+// lookup returns the validator registered for tag.
+func (a *asyncValidators) lookup(tag string) (AsyncValidatorFunc, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	fn, ok := a.funcs[tag]
+	return fn, ok
+}
+
+// plan returns (and lazily builds) the async checks for schema type t.
+func (a *asyncValidators) plan(t reflect.Type) []asyncCheck {
+	if v, ok := a.plans.Load(t); ok {
+		return v.([]asyncCheck)
+	}
+	checks := a.collect(t, t.Name(), nil, map[reflect.Type]bool{})
+	a.plans.Store(t, checks)
+	return checks
+}
+
+// collect walks the fields of struct t, including embedded and nested structs, and returns a check
+// for every registered async tag found in their validate tags. Tags after "dive" apply to elements
+// and are not collected.
+func (a *asyncValidators) collect(t reflect.Type, namespace string, index []int, visiting map[reflect.Type]bool) []asyncCheck {
+	if visiting[t] {
+		return nil
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	var checks []asyncCheck
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fieldIndex := append(append([]int(nil), index...), i)
+		fieldNamespace := namespace + "." + field.Name
+
+		var rules []string
+		for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+			if rule == "dive" {
+				break
+			}
+			rules = append(rules, rule)
+		}
+		omitEmpty := slices.Contains(rules, "omitempty")
+		for _, rule := range rules {
+			for _, alt := range strings.Split(rule, "|") {
+				tag, param, _ := strings.Cut(alt, "=")
+				if _, ok := a.lookup(tag); ok {
+					checks = append(checks, asyncCheck{
+						index:     fieldIndex,
+						namespace: fieldNamespace,
+						name:      computeFieldInfo(field).Key,
+						tag:       tag,
+						param:     param,
+						omitEmpty: omitEmpty,
+					})
+				}
+			}
+		}
+
+		if ft := derefType(field.Type); ft.Kind() == reflect.Struct && ft != reflect.TypeOf(time.Time{}) {
+			checks = append(checks, a.collect(ft, fieldNamespace, fieldIndex, visiting)...)
+		}
+	}
+	return checks
+}
+
+// runAsyncValidators runs the async checks of req (a pointer to a root schema value) concurrently
+// under the configured deadline. It returns the failing fields, a 504 HTTPError listing the fields
+// whose checks did not finish in time, or a 500 HTTPError when a check could not complete; the
+// cause of the latter is logged rather than sent to the client.
+func (af *AutoFiber) runAsyncValidators(c *fiber.Ctx, root reflect.Type, req interface{}) ([]FieldErrorDetail, error) {
+	checks := af.asyncValidators.plan(root)
+	if len(checks) == 0 {
+		return nil, nil
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(c.UserContext(), af.asyncValidators.timeout)
+	defer cancel()
+	// Checks still running at the deadline outlive the request, so they read a copy of the Locals.
+	locals := &requestLocals{c: c}
+	locals.detach()
+	ctx := localsContext{Context: timeoutCtx, locals: locals}
+
+	type result struct {
+		index int
+		pass  bool
+		err   error
+	}
+	// Buffered so that checks still running after the deadline never block.
+	results := make(chan result, len(checks))
+	pending := make(map[int]bool, len(checks))
+	reqValue := reflect.ValueOf(req).Elem()
+
+	for i, check := range checks {
+		value, ok := fieldByIndex(reqValue, check.index)
+		if !ok || (check.omitEmpty && value.IsZero()) {
+			continue
+		}
+		fn, _ := af.asyncValidators.lookup(check.tag)
+		pending[i] = true
+		go func(i int, check asyncCheck, value interface{}) {
+			pass, err := fn(ctx, value, check.param)
+			results <- result{index: i, pass: pass, err: err}
+		}(i, check, value.Interface())
+	}
+
+	failed := make(map[int]bool)
+	var checkErr error
+	for len(pending) > 0 && checkErr == nil {
+		select {
+		case r := <-results:
+			if r.err != nil && errors.Is(r.err, context.DeadlineExceeded) && ctx.Err() != nil {
+				continue // timed out: stays pending
+			}
+			delete(pending, r.index)
+			if r.err != nil {
+				checkErr = fmt.Errorf("async validator %q on %s: %w", checks[r.index].tag, checks[r.index].namespace, r.err)
+			} else if !r.pass {
+				failed[r.index] = true
+			}
+		case <-ctx.Done():
+			return nil, af.asyncValidationTimeout(c, root, checks, pending)
+		}
+	}
+	if checkErr != nil {
+		af.logger.Errorf("autofiber: %s %s: %v", c.Method(), c.Path(), checkErr)
+		return nil, &HTTPError{
+			Status:  fiber.StatusInternalServerError,
+			Message: "Validation could not be completed",
+			Code:    CodeInternalError,
+		}
+	}
+
+	trans := af.translations.forRequest(c)
+	var details []FieldErrorDetail
+	for i, check := range checks {
+		if failed[i] {
+			message := af.translations.text(trans, check.tag, check.name, check.param)
+			details = append(details, requestFieldDetail(c, root, check.namespace, message, check.tag))
+		}
+	}
+	return details, nil
+}
+
+// asyncValidationTimeout is the 504 error for checks still pending at the deadline.
+func (af *AutoFiber) asyncValidationTimeout(c *fiber.Ctx, root reflect.Type, checks []asyncCheck, pending map[int]bool) *HTTPError {
+	err := &HTTPError{
+		Status:  fiber.StatusGatewayTimeout,
+		Message: "Validation did not complete in time",
+		Code:    CodeValidationTimeout,
+	}
+	for i, check := range checks {
+		if pending[i] {
+			detail := requestFieldDetail(c, root, check.namespace, "validation did not complete in time", check.tag)
+			detail.Code = CodeValidationTimeout
+			err.Details = append(err.Details, detail)
+		}
+	}
+	return err
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex but reports false instead of panicking
+// when the path crosses a nil pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, idx := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(idx)
+	}
+	return v, true
+}
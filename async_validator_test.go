@@ -0,0 +1,206 @@
+package autofiber_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	autofiber "github.com/vuongtlt13/auto-fiber"
+)
+
+type signupOrg struct {
+	ID string `json:"id" validate:"omitempty,exists=org"`
+}
+
+type signupRequest struct {
+	Email string     `json:"email" validate:"required,email,unique_email"`
+	Org   *signupOrg `json:"org"`
+}
+
+// newAsyncApp returns an app with a /signup route using the unique_email and exists async
+// validators, and a pointer to the number of async checks run.
+func newAsyncApp(t *testing.T) (*autofiber.AutoFiber, *int32) {
+	t.Helper()
+	var calls int32
+	app := newTestApp()
+
+	assert.NoError(t, app.RegisterAsyncValidator("unique_email", func(ctx context.Context, value interface{}, _ string) (bool, error) {
+		atomic.AddInt32(&calls, 1)
+		taken := ctx.Value("taken").(map[string]bool)
+		return !taken[value.(string)], nil
+	}))
+	assert.NoError(t, app.RegisterAsyncValidator("exists", func(ctx context.Context, value interface{}, param string) (bool, error) {
+		atomic.AddInt32(&calls, 1)
+		if _, ok := ctx.Deadline(); !ok {
+			t.Error("expected a deadline on the async validation context")
+		}
+		return param == "org" && value == "acme", nil
+	}))
+	assert.NoError(t, app.RegisterTranslation("en", "unique_email", "{0} is already taken"))
+
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("taken", map[string]bool{"taken@example.com": true})
+		return c.Next()
+	})
+	app.Post("/signup", func(c *fiber.Ctx, req *signupRequest) (interface{}, error) {
+		return req, nil
+	}, autofiber.WithRequestSchema(signupRequest{}))
+	return app, &calls
+}
+
+func postSignup(t *testing.T, app *autofiber.AutoFiber, body string) (int, autofiber.ValidationRequestError) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	var payload autofiber.ValidationRequestError
+	_ = json.NewDecoder(resp.Body).Decode(&payload)
+	return resp.StatusCode, payload
+}
+
+func TestAsyncValidators(t *testing.T) {
+	app, calls := newAsyncApp(t)
+
+	status, _ := postSignup(t, app, `{"email":"new@example.com","org":{"id":"acme"}}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, int32(2), *calls)
+
+	status, payload := postSignup(t, app, `{"email":"taken@example.com","org":{"id":"nope"}}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	if assert.Len(t, payload.Details, 2) {
+		assert.Equal(t, autofiber.FieldErrorDetail{
+			Field: "email", Message: "email is already taken", Tag: "unique_email", Pointer: "/email", Source: "body",
+		}, payload.Details[0])
+		assert.Equal(t, "org.id", payload.Details[1].Field)
+		assert.Equal(t, "id failed the 'exists=org' validation", payload.Details[1].Message)
+	}
+}
+
+func TestAsyncValidators_SkippedWhenNotApplicable(t *testing.T) {
+	app, calls := newAsyncApp(t)
+
+	// Synchronous failures short-circuit async checks.
+	status, payload := postSignup(t, app, `{"email":"not-an-email"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, "email", payload.Details[0].Tag)
+	assert.Equal(t, int32(0), *calls)
+
+	// Nil nested structs and omitempty zero values are not checked.
+	status, _ = postSignup(t, app, `{"email":"new@example.com"}`)
+	assert.Equal(t, http.StatusOK, status)
+	status, _ = postSignup(t, app, `{"email":"new@example.com","org":{}}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, int32(2), *calls)
+}
+
+func TestAsyncValidators_Timeout(t *testing.T) {
+	app := autofiber.New(fiber.Config{}, autofiber.WithAsyncValidationTimeout(50*time.Millisecond))
+	release := make(chan struct{})
+	defer close(release)
+	assert.NoError(t, app.RegisterAsyncValidator("slow", func(ctx context.Context, value interface{}, _ string) (bool, error) {
+		<-release // ignores ctx: the deadline must still be enforced
+		return true, nil
+	}))
+	assert.NoError(t, app.RegisterAsyncValidator("cooperative", func(ctx context.Context, value interface{}, _ string) (bool, error) {
+		<-ctx.Done()
+		return false, ctx.Err()
+	}))
+
+	type Req struct {
+		Name  string `json:"name" validate:"slow"`
+		Email string `json:"email" validate:"cooperative"`
+	}
+	app.Post("/slow", func(c *fiber.Ctx, req *Req) (interface{}, error) {
+		return req, nil
+	}, autofiber.WithRequestSchema(Req{}))
+
+	req := httptest.NewRequest(http.MethodPost, "/slow", strings.NewReader(`{"name":"x","email":"y"}`))
+	req.Header.Set("Content-Type", "application/json")
+	start := time.Now()
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, http.StatusGatewayTimeout, resp.StatusCode)
+
+	var body autofiber.HTTPError
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, autofiber.CodeValidationTimeout, body.Code)
+	if assert.Len(t, body.Details, 2) {
+		assert.Equal(t, "name", body.Details[0].Field)
+		assert.Equal(t, autofiber.CodeValidationTimeout, body.Details[0].Code)
+		assert.Equal(t, "email", body.Details[1].Field)
+	}
+}
+
+func TestAsyncValidators_OutliveRequest(t *testing.T) {
+	app := autofiber.New(fiber.Config{}, autofiber.WithAsyncValidationTimeout(20*time.Millisecond))
+	release := make(chan struct{})
+	seen := make(chan interface{}, 1)
+	assert.NoError(t, app.RegisterAsyncValidator("late", func(ctx context.Context, value interface{}, _ string) (bool, error) {
+		<-release
+		seen <- ctx.Value("tenant")
+		return true, nil
+	}))
+
+	type Req struct {
+		Name string `json:"name" validate:"late"`
+	}
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("tenant", c.Get("X-Tenant"))
+		return c.Next()
+	})
+	app.Post("/late", func(c *fiber.Ctx, req *Req) (interface{}, error) {
+		return req, nil
+	}, autofiber.WithRequestSchema(Req{}))
+
+	req := httptest.NewRequest(http.MethodPost, "/late", strings.NewReader(`{"name":"x"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tenant", "acme")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusGatewayTimeout, resp.StatusCode)
+
+	// The check resumes after its request completed and still sees the request's Locals.
+	_, err = app.Test(httptest.NewRequest(http.MethodGet, "/other", nil))
+	assert.NoError(t, err)
+	close(release)
+	assert.Equal(t, "acme", <-seen)
+}
+
+func TestAsyncValidators_CheckError(t *testing.T) {
+	logger := &recordingLogger{}
+	app := autofiber.New(fiber.Config{}, autofiber.WithLogger(logger))
+	assert.NoError(t, app.RegisterAsyncValidator("lookup", func(ctx context.Context, value interface{}, _ string) (bool, error) {
+		return false, errors.New("dial tcp 10.0.0.5:5432: connection refused")
+	}))
+
+	type Req struct {
+		Name string `json:"name" validate:"lookup"`
+	}
+	app.Post("/lookup", func(c *fiber.Ctx, req *Req) (interface{}, error) {
+		return req, nil
+	}, autofiber.WithRequestSchema(Req{}))
+
+	req := httptest.NewRequest(http.MethodPost, "/lookup", strings.NewReader(`{"name":"x"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+	var body autofiber.HTTPError
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, autofiber.CodeInternalError, body.Code)
+	assert.NotContains(t, body.Message, "connection refused")
+	if assert.Len(t, logger.errors, 1) {
+		assert.Contains(t, logger.errors[0], "connection refused")
+	}
+}
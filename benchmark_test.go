@@ -38,3 +38,25 @@ func BenchmarkDocsGenerator_GenerateOpenAPISpec(b *testing.B) {
 		assert.NotNil(b, spec)
 	}
 }
+
+func BenchmarkMap(b *testing.B) {
+	type Src struct {
+		ID    int      `json:"id"`
+		Name  string   `json:"name"`
+		Tags  []string `json:"tags"`
+		Score float32  `json:"score"`
+	}
+	type Dst struct {
+		UserID int      `map:"id"`
+		Name   string   `json:"name"`
+		Tags   []string `json:"tags"`
+		Score  float64  `json:"score"`
+	}
+	src := Src{ID: 1, Name: "John", Tags: []string{"a", "b"}, Score: 1.5}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := autofiber.Map[Dst](src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
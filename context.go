@@ -0,0 +1,106 @@
+// Package autofiber provides the context.Context passed to transport-agnostic handlers.
+package autofiber
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// contextType is the reflect type of context.Context, used to recognize context-first handlers.
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// requestContext derives the context for a context-first handler from the request:
+//   - it inherits c.UserContext(), so values and deadlines set by middleware carry over;
+//   - it is cancelled when the client disconnects, when the server shuts down, when the route's
+//     WithTimeout elapses and when the returned cancel func is called;
+//   - string keys not found in the parent context are looked up in c.Locals, so request-scoped
+//     values such as the authenticated user or the request ID are visible to service code.
+//
+// Disconnects are detected while the handler runs, on platforms supported by watchDisconnect
+// and for connections exposing their file descriptor; the watch ends with the returned cancel
+// func or detach.
+func requestContext(c *fiber.Ctx, opts *RouteOptions) (localsContext, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(c.UserContext())
+	stop := context.AfterFunc(c.Context(), cancel)
+	unwatch := func() {}
+	if conn := c.Context().Conn(); conn != nil {
+		unwatch = sync.OnceFunc(watchDisconnect(conn, cancel))
+	}
+	locals := &requestLocals{c: c}
+	if opts.Timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, opts.Timeout)
+		return localsContext{Context: ctx, locals: locals, unwatch: unwatch}, func() {
+			unwatch()
+			cancelTimeout()
+			stop()
+			cancel()
+		}
+	}
+	return localsContext{Context: ctx, locals: locals, unwatch: unwatch}, func() {
+		unwatch()
+		stop()
+		cancel()
+	}
+}
+
+// localsContext falls back to the request's Locals for string keys.
+type localsContext struct {
+	context.Context
+	locals  *requestLocals
+	unwatch func()
+}
+
+// Value implements context.Context.
+func (l localsContext) Value(key interface{}) interface{} {
+	if v := l.Context.Value(key); v != nil {
+		return v
+	}
+	if name, ok := key.(string); ok {
+		return l.locals.get(name)
+	}
+	return nil
+}
+
+// detach copies the request's string-keyed Locals so the context stays usable after the handler
+// returns and Fiber reuses the *fiber.Ctx, as when the handler's result is streamed. It stops
+// watching for disconnects, which fasthttp's reads would race with; the stream detects them
+// when writing instead.
+func (l localsContext) detach() {
+	l.unwatch()
+	l.locals.detach()
+}
+
+// requestLocals reads the Locals of a request, or a copy of them once detached.
+type requestLocals struct {
+	mu     sync.RWMutex
+	c      *fiber.Ctx
+	copied map[string]interface{}
+}
+
+// get returns the local named name.
+func (r *requestLocals) get(name string) interface{} {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.c != nil {
+		return r.c.Locals(name)
+	}
+	return r.copied[name]
+}
+
+// detach copies the Locals and stops reading them from the *fiber.Ctx.
+func (r *requestLocals) detach() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.c == nil {
+		return
+	}
+	r.copied = make(map[string]interface{})
+	r.c.Context().VisitUserValues(func(key []byte, value interface{}) {
+		r.copied[string(key)] = value
+	})
+	r.c = nil
+}
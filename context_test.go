@@ -0,0 +1,106 @@
+package autofiber_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autofiber "github.com/vuongtlt13/auto-fiber"
+)
+
+type ctxKey struct{}
+
+type greetRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type greeting struct {
+	Message string `json:"message"`
+	User    string `json:"user"`
+	Trace   string `json:"trace"`
+}
+
+// greetService is transport-agnostic: it only sees a context.Context.
+func greetService(ctx context.Context, req *greetRequest) (*greeting, error) {
+	user, _ := ctx.Value("user").(string)
+	trace, _ := ctx.Value(ctxKey{}).(string)
+	return &greeting{Message: "hello " + req.Name, User: user, Trace: trace}, nil
+}
+
+func TestContextHandler_WithRequest(t *testing.T) {
+	app := autofiber.New(fiber.Config{})
+	app.Post("/greet", greetService,
+		autofiber.WithRequestSchema(greetRequest{}),
+		autofiber.WithMiddleware(func(c *fiber.Ctx) error {
+			c.Locals("user", "ada")
+			c.SetUserContext(context.WithValue(c.UserContext(), ctxKey{}, "trace-1"))
+			return c.Next()
+		}),
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/greet", strings.NewReader(`{"name":"Bob"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body greeting
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, greeting{Message: "hello Bob", User: "ada", Trace: "trace-1"}, body)
+}
+
+func TestContextHandler_WithoutRequest(t *testing.T) {
+	app := autofiber.New(fiber.Config{})
+	app.Get("/ping", func(ctx context.Context) (interface{}, error) {
+		return fiber.Map{"ok": ctx.Err() == nil}, nil
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/ping", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body map[string]bool
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.True(t, body["ok"])
+}
+
+func TestContextHandler_Timeout(t *testing.T) {
+	app := autofiber.New(fiber.Config{}, autofiber.WithErrorHandler(func(c *fiber.Ctx, err error) error {
+		return c.Status(fiber.StatusGatewayTimeout).SendString(err.Error())
+	}))
+	app.Get("/slow", func(ctx context.Context) (interface{}, error) {
+		select {
+		case <-ctx.Done():
+			return nil, autofiber.NewHTTPError(fiber.StatusGatewayTimeout, ctx.Err().Error())
+		case <-time.After(time.Second):
+			return "done", nil
+		}
+	}, autofiber.WithTimeout(10*time.Millisecond))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/slow", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusGatewayTimeout, resp.StatusCode)
+}
+
+func TestContextHandler_CancelledAfterReturn(t *testing.T) {
+	var captured context.Context
+	app := autofiber.New(fiber.Config{})
+	app.Get("/capture", func(ctx context.Context) (interface{}, error) {
+		captured = ctx
+		return "ok", nil
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/capture", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NotNil(t, captured)
+	assert.True(t, errors.Is(captured.Err(), context.Canceled))
+}
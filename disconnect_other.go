@@ -0,0 +1,11 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+// Package autofiber leaves client disconnects undetected on platforms without MSG_PEEK support.
+package autofiber
+
+import "net"
+
+// watchDisconnect does not detect disconnects on this platform.
+func watchDisconnect(conn net.Conn, onClose func()) (stop func()) {
+	return func() {}
+}
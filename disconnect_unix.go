@@ -0,0 +1,59 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+// Package autofiber detects client disconnects by peeking at the request's connection.
+package autofiber
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+	"syscall"
+	"time"
+)
+
+// watchDisconnect calls onClose when the peer closes conn while a handler runs. fasthttp reads
+// the whole request before calling the handler, so the connection stays idle until the peer
+// sends a pipelined request (watching stops) or closes it (onClose is called). stop ends the
+// watch and must be called before the handler returns, since fasthttp reads conn afterwards.
+// Connections that do not expose their file descriptor are not watched.
+func watchDisconnect(conn net.Conn, onClose func()) (stop func()) {
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		conn = tlsConn.NetConn()
+	}
+	sc, ok := conn.(syscall.Conn)
+	if !ok {
+		return func() {}
+	}
+	raw, err := sc.SyscallConn()
+	if err != nil {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		closed := false
+		buf := make([]byte, 1)
+		// The runtime poller parks the read until the connection is readable; the peek leaves
+		// any data for fasthttp.
+		err := raw.Read(func(fd uintptr) bool {
+			n, _, err := syscall.Recvfrom(int(fd), buf, syscall.MSG_PEEK)
+			if errors.Is(err, syscall.EAGAIN) || errors.Is(err, syscall.EINTR) {
+				return false
+			}
+			closed = (err == nil && n == 0) || errors.Is(err, syscall.ECONNRESET)
+			return true
+		})
+		if err == nil && closed {
+			onClose()
+		}
+	}()
+
+	return func() {
+		// An expired deadline wakes the parked read; fasthttp sets its own deadlines before
+		// reading the next request, so clearing it afterwards is safe.
+		_ = conn.SetReadDeadline(time.Unix(1, 0))
+		<-done
+		_ = conn.SetReadDeadline(time.Time{})
+	}
+}
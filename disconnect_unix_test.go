@@ -0,0 +1,89 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package autofiber_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autofiber "github.com/vuongtlt13/auto-fiber"
+)
+
+// listen serves app on a local port until the test ends.
+func listen(t *testing.T, app *autofiber.AutoFiber) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = app.App.Listener(ln) }()
+	// Closing the listener rather than calling Shutdown avoids an unsynchronized read of the
+	// server's done channel in fasthttp's RequestCtx.Done.
+	t.Cleanup(func() { _ = ln.Close() })
+	return ln.Addr().String()
+}
+
+func TestContextHandler_CancelledOnDisconnect(t *testing.T) {
+	started := make(chan struct{})
+	cancelled := make(chan error, 1)
+	app := autofiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Get("/slow", func(ctx context.Context) (interface{}, error) {
+		close(started)
+		select {
+		case <-ctx.Done():
+			cancelled <- ctx.Err()
+		case <-time.After(5 * time.Second):
+			cancelled <- nil
+		}
+		return nil, ctx.Err()
+	})
+
+	conn, err := net.Dial("tcp", listen(t, app))
+	require.NoError(t, err)
+	_, err = fmt.Fprint(conn, "GET /slow HTTP/1.1\r\nHost: example.com\r\n\r\n")
+	require.NoError(t, err)
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the handler was not called")
+	}
+	require.NoError(t, conn.Close())
+
+	select {
+	case err := <-cancelled:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(2 * time.Second):
+		t.Fatal("the context was not cancelled on disconnect")
+	}
+}
+
+func TestContextHandler_KeepAliveAfterWatch(t *testing.T) {
+	for _, config := range []fiber.Config{
+		{DisableStartupMessage: true},
+		{DisableStartupMessage: true, ReadTimeout: time.Second},
+	} {
+		app := autofiber.New(config)
+		app.Get("/ok", func(ctx context.Context) (interface{}, error) {
+			return "ok", ctx.Err()
+		})
+		addr := listen(t, app)
+
+		// Requests on a reused connection are read normally once the watch has ended.
+		client := &http.Client{Transport: &http.Transport{MaxConnsPerHost: 1}}
+		for i := 0; i < 3; i++ {
+			resp, err := client.Get("http://" + addr + "/ok")
+			require.NoError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, `"ok"`, string(body))
+		}
+	}
+}
@@ -5,8 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
 )
 
 // OpenAPISpec represents the OpenAPI 3.0 specification structure.
@@ -17,6 +21,7 @@ type OpenAPISpec struct {
 	Paths      map[string]OpenAPIPath `json:"paths"`
 	Components OpenAPIComponents      `json:"components,omitempty"`
 	Tags       []OpenAPITag           `json:"tags,omitempty"`
+	ErrorCodes []ErrorCode            `json:"x-error-codes,omitempty"` // error catalog, set by ServeErrorCatalog
 }
 
 // OpenAPIInfo represents the API information including title, description, version, and contact details.
@@ -77,6 +82,8 @@ type OpenAPIParameter struct {
 	Description string         `json:"description,omitempty"`
 	Required    bool           `json:"required,omitempty"`
 	Schema      *OpenAPISchema `json:"schema,omitempty"`
+	Style       string         `json:"style,omitempty"`
+	Explode     *bool          `json:"explode,omitempty"`
 }
 
 // OpenAPIRequestBody represents a request body for an API operation.
@@ -94,9 +101,18 @@ type OpenAPIMediaType struct {
 // OpenAPIResponse represents a response for an API operation.
 type OpenAPIResponse struct {
 	Description string                      `json:"description"`
+	Headers     map[string]OpenAPIHeader    `json:"headers,omitempty"`
 	Content     map[string]OpenAPIMediaType `json:"content,omitempty"`
 }
 
+// OpenAPIHeader represents a header sent with a response.
+type OpenAPIHeader struct {
+	Description string         `json:"description,omitempty"`
+	Schema      *OpenAPISchema `json:"schema,omitempty"`
+	Style       string         `json:"style,omitempty"`
+	Explode     *bool          `json:"explode,omitempty"`
+}
+
 // OpenAPISchema represents a JSON schema for request/response data structures.
 type OpenAPISchema struct {
 	Type        string                   `json:"type,omitempty"`
@@ -107,6 +123,8 @@ type OpenAPISchema struct {
 	Items       *OpenAPISchema           `json:"items,omitempty"`
 	Ref         string                   `json:"$ref,omitempty"`
 	Example     interface{}              `json:"example,omitempty"`
+	Nullable    bool                     `json:"nullable,omitempty"`
+	Enum        []interface{}            `json:"enum,omitempty"`
 }
 
 // OpenAPIComponents represents reusable components like schemas and security schemes.
@@ -132,10 +150,14 @@ type RouteInfo struct {
 
 // DocsGenerator handles API documentation generation and OpenAPI specification creation.
 type DocsGenerator struct {
-	routes   []RouteInfo
-	schemas  map[string]OpenAPISchema
-	tags     map[string]OpenAPITag
-	DocsInfo *OpenAPIInfo
+	routes         []RouteInfo
+	schemas        map[string]OpenAPISchema
+	tags           map[string]OpenAPITag
+	DocsInfo       *OpenAPIInfo
+	problemDetails bool          // document error responses as application/problem+json
+	errorCatalog   *errorCatalog // published as x-error-codes when set
+	encoders       *encoders     // media types listed under success responses when set
+	envelope       bool          // document JSON bodies wrapped in the response envelope
 }
 
 // NewDocsGenerator creates a new documentation generator with the specified base path.
@@ -166,8 +188,15 @@ func (dg *DocsGenerator) AddRoute(path, method string, handler interface{}, opti
 		if options.RequestSchema != nil {
 			dg.addSchema(options.RequestSchema)
 		}
-		if options.ResponseSchema != nil {
+		if options.ResponseSchema != nil && !isFileResponse(options.ResponseSchema) {
 			dg.addSchema(options.ResponseSchema)
+		} else if itemType, ok := handlerStreamItemType(handler); ok && derefType(itemType).Kind() == reflect.Struct {
+			dg.addSchema(reflect.New(derefType(itemType)).Elem().Interface())
+		}
+		for _, resp := range options.Responses {
+			if resp.Schema != nil && !isFileResponse(resp.Schema) {
+				dg.addSchema(resp.Schema)
+			}
 		}
 
 		// Add tags
@@ -269,6 +298,13 @@ func (dg *DocsGenerator) GenerateOpenAPISpec() *OpenAPISpec {
 		spec.Paths[openAPIPath] = existingPath
 	}
 
+	if dg.problemDetails {
+		spec.Components.Schemas["ProblemDetails"] = problemDetailsSchema()
+	}
+	if dg.errorCatalog != nil {
+		spec.ErrorCodes = dg.errorCatalog.list()
+	}
+
 	// Add bearerAuth security scheme if needed
 	if needsBearerAuth {
 		spec.Components.SecuritySchemes["bearerAuth"] = map[string]string{
@@ -319,6 +355,11 @@ func (dg *DocsGenerator) generatePathWithSecurity(route RouteInfo) (OpenAPIPath,
 		operation.Parameters = dg.generatePathParameters(route.Path)
 	}
 
+	// Document the ?fields= and ?expand= parameters of routes with WithFields / WithExpand
+	if route.Options != nil {
+		operation.Parameters = appendSelectionParameters(operation.Parameters, route.Options)
+	}
+
 	// Apply JWT auth security if requested for this route (skip if already added via schema field)
 	if route.Options != nil && route.Options.RequireJWTAuth && !hasBearer {
 		operation.Security = []map[string][]string{{"bearerAuth": {}}}
@@ -640,8 +681,59 @@ func (dg *DocsGenerator) generatePathParameters(path string) []OpenAPIParameter
 }
 
 // generateResponses generates responses for the operation including success and error responses.
-// It creates standard 200, 400, and 500 responses with appropriate schemas.
+// It creates the success response (200 unless set with WithStatus, 204 without content for
+// error-only handlers) and standard 400 and 500 responses with appropriate schemas, then replaces
+// them with the responses declared with WithResponse. Declared 2xx responses replace the default
+// success response unless its status was set with WithStatus.
 func (dg *DocsGenerator) generateResponses(route RouteInfo) map[string]OpenAPIResponse {
+	responses, successKey := dg.defaultResponses(route)
+	if route.Options == nil {
+		return responses
+	}
+	success := responses[successKey]
+	replaceSuccess := route.Options.Status == 0 && declaresSuccess(route.Options.Responses)
+	if replaceSuccess {
+		delete(responses, successKey)
+	}
+	for status, declared := range route.Options.Responses {
+		key := strconv.Itoa(status)
+		resp := OpenAPIResponse{Description: declared.Description}
+		if resp.Description == "" {
+			resp.Description = utils.StatusMessage(status)
+		}
+		if file, ok := declared.Schema.(documentedFile); ok {
+			resp.Content = binaryContent(file.mediaType())
+		} else if declared.Schema != nil {
+			resp.Content = dg.schemaContent(route.Options, declared.Schema)
+		}
+		// Keep the documented headers of the success response.
+		resp.Headers = responses[key].Headers
+		if resp.Headers == nil && replaceSuccess && isSuccessStatus(status) {
+			resp.Headers = success.Headers
+		}
+		responses[key] = resp
+	}
+	return responses
+}
+
+// declaresSuccess reports whether responses declares a 2xx status.
+func declaresSuccess(responses map[int]RouteResponse) bool {
+	for status := range responses {
+		if isSuccessStatus(status) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSuccessStatus reports whether status is a 2xx status code.
+func isSuccessStatus(status int) bool {
+	return status >= 200 && status < 300
+}
+
+// defaultResponses generates the responses of an operation from its handler and options, without
+// the responses declared with WithResponse. It also returns the key of the success response.
+func (dg *DocsGenerator) defaultResponses(route RouteInfo) (map[string]OpenAPIResponse, string) {
 	responses := make(map[string]OpenAPIResponse)
 
 	// Default success response
@@ -649,19 +741,93 @@ func (dg *DocsGenerator) generateResponses(route RouteInfo) map[string]OpenAPIRe
 		Description: "Successful operation",
 	}
 
-	// Add response schema if provided
+	// Add response schema if provided, under every media type the route can produce
 	if route.Options != nil && route.Options.ResponseSchema != nil {
-		schemaName := GetSchemaName(route.Options.ResponseSchema)
+		successResponse.Content = dg.schemaContent(route.Options, route.Options.ResponseSchema)
+	}
+
+	// Streamed results are documented as SSE and NDJSON streams of the item schema.
+	if itemType, ok := handlerStreamItemType(route.Handler); ok {
+		itemSchema := dg.convertFieldTypeToSchema(itemType)
+		if route.Options != nil && route.Options.ResponseSchema != nil {
+			itemSchema = OpenAPISchema{Ref: fmt.Sprintf("#/components/schemas/%s", GetSchemaName(route.Options.ResponseSchema))}
+		}
+		successResponse.Description = "Stream of items, as Server-Sent Events or newline-delimited JSON"
 		successResponse.Content = map[string]OpenAPIMediaType{
-			"application/json": {
-				Schema: &OpenAPISchema{
-					Ref: fmt.Sprintf("#/components/schemas/%s", schemaName),
+			StreamContentTypeSSE:    {Schema: &itemSchema},
+			StreamContentTypeNDJSON: {Schema: &itemSchema},
+		}
+	}
+
+	// File responses are documented as binary content, with partial responses when they support Range.
+	mediaType, ranges, isFile := routeFileResponse(route)
+	if isFile {
+		successResponse.Content = binaryContent(mediaType)
+		successResponse.Headers = map[string]OpenAPIHeader{
+			fiber.HeaderContentDisposition: {Description: "attachment or inline, with the suggested file name", Schema: &OpenAPISchema{Type: "string"}},
+		}
+		if ranges {
+			successResponse.Headers[fiber.HeaderAcceptRanges] = OpenAPIHeader{Description: "bytes", Schema: &OpenAPISchema{Type: "string"}}
+			responses[strconv.Itoa(fiber.StatusPartialContent)] = OpenAPIResponse{
+				Description: "Requested byte range of the file",
+				Headers: map[string]OpenAPIHeader{
+					fiber.HeaderContentRange: {Description: "Range sent and the full size, e.g. bytes 0-1023/4096", Schema: &OpenAPISchema{Type: "string"}},
 				},
-			},
+				Content: binaryContent(mediaType),
+			}
+			responses[strconv.Itoa(fiber.StatusRequestedRangeNotSatisfiable)] = OpenAPIResponse{
+				Description: utils.StatusMessage(fiber.StatusRequestedRangeNotSatisfiable),
+			}
 		}
 	}
 
-	responses["200"] = successResponse
+	if route.Options != nil {
+		for name, description := range route.Options.ResponseHeaders {
+			if successResponse.Headers == nil {
+				successResponse.Headers = make(map[string]OpenAPIHeader)
+			}
+			successResponse.Headers[name] = OpenAPIHeader{
+				Description: description,
+				Schema:      &OpenAPISchema{Type: "string"},
+			}
+		}
+	}
+
+	successStatus := fiber.StatusOK
+	if route.Options != nil && !isFile {
+		successStatus = route.Options.successStatus()
+	}
+	if isNoContentHandler(route.Handler) {
+		// Error-only handlers send no body.
+		successResponse.Content = nil
+		successStatus = fiber.StatusNoContent
+		if route.Options != nil {
+			successStatus = route.Options.noContentStatus()
+		}
+		if successStatus == fiber.StatusNoContent {
+			successResponse.Description = utils.StatusMessage(fiber.StatusNoContent)
+		}
+	}
+	successKey := strconv.Itoa(successStatus)
+	responses[successKey] = successResponse
+
+	if route.Options != nil && len(route.Options.Errors) > 0 {
+		for _, status := range route.Options.Errors {
+			responses[strconv.Itoa(status)] = dg.errorResponse(status)
+		}
+		return responses, successKey
+	}
+
+	if dg.problemDetails {
+		dg.addProblemResponses(route, responses)
+		return responses, successKey
+	}
+
+	if dg.envelope {
+		responses["400"] = dg.errorResponse(fiber.StatusBadRequest)
+		responses["500"] = dg.errorResponse(fiber.StatusInternalServerError)
+		return responses, successKey
+	}
 
 	// Add common error responses
 	responses["400"] = OpenAPIResponse{
@@ -693,7 +859,90 @@ func (dg *DocsGenerator) generateResponses(route RouteInfo) map[string]OpenAPIRe
 		},
 	}
 
-	return responses
+	return responses, successKey
+}
+
+// schemaContent documents a body of the given schema under every media type the route can
+// produce it in, wrapping JSON in the envelope when WithEnvelope is enabled.
+func (dg *DocsGenerator) schemaContent(opts *RouteOptions, body interface{}) map[string]OpenAPIMediaType {
+	schema := &OpenAPISchema{
+		Ref: fmt.Sprintf("#/components/schemas/%s", GetSchemaName(body)),
+	}
+	content := map[string]OpenAPIMediaType{}
+	for _, mediaType := range dg.producedMediaTypes(opts, body) {
+		if dg.envelope && mediaType == fiber.MIMEApplicationJSON {
+			content[mediaType] = OpenAPIMediaType{Schema: envelopeSchema(schema)}
+			continue
+		}
+		content[mediaType] = OpenAPIMediaType{Schema: schema}
+	}
+	return content
+}
+
+// producedMediaTypes lists the media types a route can encode a body of the given schema in,
+// defaulting to JSON when no encoders are known.
+func (dg *DocsGenerator) producedMediaTypes(opts *RouteOptions, body interface{}) []string {
+	if dg.encoders == nil {
+		return []string{fiber.MIMEApplicationJSON}
+	}
+	var mediaTypes []string
+	for _, enc := range dg.encoders.forRoute(opts, reflect.TypeOf(body)) {
+		mediaTypes = append(mediaTypes, enc.MediaType())
+	}
+	return mediaTypes
+}
+
+// errorResponse documents an error status, using the problem details schema when enabled, the
+// enveloped error when WithEnvelope is, and the HTTPError body otherwise.
+func (dg *DocsGenerator) errorResponse(status int) OpenAPIResponse {
+	if dg.problemDetails {
+		return OpenAPIResponse{
+			Description: utils.StatusMessage(status),
+			Content: map[string]OpenAPIMediaType{
+				ProblemContentType: {
+					Schema: &OpenAPISchema{Ref: "#/components/schemas/ProblemDetails"},
+				},
+			},
+		}
+	}
+	if dg.envelope {
+		return OpenAPIResponse{
+			Description: utils.StatusMessage(status),
+			Content: map[string]OpenAPIMediaType{
+				"application/json": {Schema: envelopeErrorSchema()},
+			},
+		}
+	}
+	return OpenAPIResponse{
+		Description: utils.StatusMessage(status),
+		Content: map[string]OpenAPIMediaType{
+			"application/json": {
+				Schema: &OpenAPISchema{
+					Type:     "object",
+					Required: []string{"error"},
+					Properties: map[string]OpenAPISchema{
+						"error":   {Type: "string"},
+						"code":    {Type: "string"},
+						"details": {Type: "array", Items: &OpenAPISchema{Type: "object"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+// addProblemResponses documents the problem details responses AutoFiber can generate for route.
+func (dg *DocsGenerator) addProblemResponses(route RouteInfo, responses map[string]OpenAPIResponse) {
+	statuses := []int{fiber.StatusBadRequest, fiber.StatusInternalServerError}
+	if route.Options != nil && route.Options.RequestSchema != nil {
+		statuses = append(statuses, fiber.StatusUnprocessableEntity)
+	}
+	if route.Options != nil && route.Options.RequireJWTAuth {
+		statuses = append(statuses, fiber.StatusUnauthorized)
+	}
+	for _, status := range statuses {
+		responses[strconv.Itoa(status)] = dg.errorResponse(status)
+	}
 }
 
 // addSchema adds a schema to the components section of the OpenAPI specification.
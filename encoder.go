@@ -0,0 +1,413 @@
+// Package autofiber provides pluggable response encoders selected from the request's Accept header.
+package autofiber
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Encoder writes response bodies in one media type.
+type Encoder interface {
+	// MediaType is the Content-Type of encoded bodies, matched against the Accept header.
+	MediaType() string
+	// Encode serializes a handler result.
+	Encode(v interface{}) ([]byte, error)
+}
+
+// NewEncoder returns an Encoder for mediaType backed by marshal, which makes it easy to plug in
+// other formats from a third-party library.
+//
+// Example:
+//
+//	import "gopkg.in/yaml.v3"
+//
+//	app := autofiber.New(fiber.Config{},
+//	    autofiber.WithEncoders(autofiber.NewEncoder("application/yaml", yaml.Marshal)),
+//	)
+func NewEncoder(mediaType string, marshal func(v interface{}) ([]byte, error)) Encoder {
+	return funcEncoder{mediaType: mediaType, marshal: marshal}
+}
+
+// XMLEncoder returns an Encoder for application/xml using encoding/xml, so response types
+// use xml struct tags. encoding/xml cannot encode maps, so it is not offered for map results nor
+// for structs with map fields.
+func XMLEncoder() Encoder {
+	return xmlEncoder{}
+}
+
+// MessagePackEncoder returns an Encoder for application/msgpack. Struct fields are named by
+// their json tags, so MessagePack bodies carry the same keys as JSON ones.
+func MessagePackEncoder() Encoder {
+	return msgpackEncoder{}
+}
+
+// CSVEncoder returns an Encoder for text/csv. It only encodes slices of structs: the header row
+// holds the json names of the struct fields and each element becomes a row. It is not offered
+// for other results, nor listed in the docs of routes whose response schema is not a slice.
+func CSVEncoder() Encoder {
+	return csvEncoder{}
+}
+
+// WithEncoders adds response encoders to the app. JSON is always registered and remains the
+// default when the request has no Accept header. Each route's response is encoded with the
+// registered encoder best matching the Accept header, and requests accepting none of them get
+// 406 Not Acceptable. Restrict a route to some media types with WithProduces.
+//
+// Example:
+//
+//	app := autofiber.New(fiber.Config{},
+//	    autofiber.WithEncoders(autofiber.XMLEncoder(), autofiber.CSVEncoder()),
+//	)
+func WithEncoders(encoders ...Encoder) AutoFiberOption {
+	return func(af *AutoFiber) {
+		af.encoders.add(encoders...)
+	}
+}
+
+// funcEncoder is an Encoder backed by a marshal function.
+type funcEncoder struct {
+	mediaType string
+	marshal   func(v interface{}) ([]byte, error)
+}
+
+// MediaType implements Encoder.
+func (e funcEncoder) MediaType() string { return e.mediaType }
+
+// Encode implements Encoder.
+func (e funcEncoder) Encode(v interface{}) ([]byte, error) { return e.marshal(v) }
+
+// typeRestricted is implemented by built-in encoders that only support some result types.
+type typeRestricted interface {
+	supports(t reflect.Type) bool
+}
+
+// encoderSupports reports whether enc can encode values of type t.
+func encoderSupports(enc Encoder, t reflect.Type) bool {
+	restricted, ok := enc.(typeRestricted)
+	return !ok || (t != nil && restricted.supports(t))
+}
+
+// encoders is the app's ordered set of response encoders; the first one is the JSON default.
+type encoders struct {
+	list []Encoder
+}
+
+// newEncoders creates the registry with the JSON encoder backed by marshal.
+func newEncoders(marshal utils.JSONMarshal) *encoders {
+	return &encoders{list: []Encoder{NewEncoder(fiber.MIMEApplicationJSON, marshal)}}
+}
+
+// add registers encs, replacing registered encoders with the same media type.
+func (e *encoders) add(encs ...Encoder) {
+	for _, enc := range encs {
+		replaced := false
+		for i, existing := range e.list {
+			if existing.MediaType() == enc.MediaType() {
+				e.list[i] = enc
+				replaced = true
+			}
+		}
+		if !replaced {
+			e.list = append(e.list, enc)
+		}
+	}
+}
+
+// candidates returns the encoders a route may use: all of them, or those listed with
+// WithProduces in the listed order.
+func (e *encoders) candidates(opts *RouteOptions) []Encoder {
+	if len(opts.Produces) == 0 {
+		return e.list
+	}
+	var candidates []Encoder
+	for _, mediaType := range opts.Produces {
+		for _, enc := range e.list {
+			if enc.MediaType() == mediaType {
+				candidates = append(candidates, enc)
+			}
+		}
+	}
+	return candidates
+}
+
+// forRoute returns the candidate encoders of a route that support values of type t.
+func (e *encoders) forRoute(opts *RouteOptions, t reflect.Type) []Encoder {
+	var usable []Encoder
+	for _, enc := range e.candidates(opts) {
+		if encoderSupports(enc, t) {
+			usable = append(usable, enc)
+		}
+	}
+	return usable
+}
+
+// routeOffers returns the media types a route can respond with, so requests accepting none of
+// them are rejected before the handler runs. It reports false when the route writes no encoded
+// body (error-only handlers, file responses and streams). When neither the response schemas nor
+// the handler's signature tell the result type, every candidate encoder and the stream media
+// types are offered.
+func (e *encoders) routeOffers(handler interface{}, opts *RouteOptions) ([]string, bool) {
+	if isNoContentHandler(handler) {
+		return nil, false
+	}
+	if _, ok := handlerStreamItemType(handler); ok {
+		return nil, false
+	}
+	if _, _, ok := routeFileResponse(RouteInfo{Handler: handler, Options: opts}); ok {
+		return nil, false
+	}
+
+	var types []reflect.Type
+	if opts.ResponseSchema != nil {
+		types = append(types, reflect.TypeOf(opts.ResponseSchema))
+	}
+	for status, response := range opts.Responses {
+		if status >= 200 && status < 300 && response.Schema != nil {
+			types = append(types, reflect.TypeOf(response.Schema))
+		}
+	}
+	if t := reflect.TypeOf(handler); len(types) == 0 && t.NumOut() == 2 && t.Out(0).Kind() != reflect.Interface {
+		types = append(types, responseBodyType(t.Out(0)))
+	}
+	for _, t := range types {
+		if isFileResponse(reflect.New(derefType(t)).Elem().Interface()) || isStreamType(t) {
+			return nil, false
+		}
+	}
+
+	var offers []string
+	for _, enc := range e.candidates(opts) {
+		usable := len(types) == 0
+		for _, t := range types {
+			usable = usable || encoderSupports(enc, t)
+		}
+		if usable {
+			offers = append(offers, enc.MediaType())
+		}
+	}
+	if len(types) == 0 {
+		offers = append(offers, StreamContentTypeSSE, StreamContentTypeNDJSON)
+	}
+	return offers, true
+}
+
+// negotiate picks the encoder for data from the Accept header, or reports false when the request
+// accepts none of the route's encoders.
+func (e *encoders) negotiate(c *fiber.Ctx, opts *RouteOptions, data interface{}) (Encoder, bool) {
+	usable := e.forRoute(opts, reflect.TypeOf(data))
+	if len(usable) == 0 {
+		return nil, false
+	}
+	offers := make([]string, len(usable))
+	for i, enc := range usable {
+		offers[i] = enc.MediaType()
+	}
+	accepted := c.Accepts(offers...)
+	for _, enc := range usable {
+		if enc.MediaType() == accepted {
+			return enc, true
+		}
+	}
+	return nil, false
+}
+
+// notAcceptable is the error for requests accepting none of the route's media types.
+func notAcceptable(c *fiber.Ctx) *HTTPError {
+	return &HTTPError{
+		Status:  fiber.StatusNotAcceptable,
+		Message: fmt.Sprintf("None of the accepted media types (%s) can be produced", c.Get(fiber.HeaderAccept)),
+		Code:    CodeNotAcceptable,
+	}
+}
+
+// xmlEncoder encodes results with encoding/xml.
+type xmlEncoder struct{}
+
+// MediaType implements Encoder.
+func (xmlEncoder) MediaType() string { return fiber.MIMEApplicationXML }
+
+// Encode implements Encoder.
+func (xmlEncoder) Encode(v interface{}) ([]byte, error) { return xml.Marshal(v) }
+
+// supports implements typeRestricted.
+func (xmlEncoder) supports(t reflect.Type) bool {
+	return xmlSupports(t, map[reflect.Type]bool{})
+}
+
+// xmlSupports reports whether encoding/xml can encode t, which it cannot for maps, including
+// maps in fields, slice elements or pointers.
+func xmlSupports(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	t = derefType(t)
+	for t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = derefType(t.Elem())
+	}
+	switch {
+	case t.Implements(xmlMarshalerType) || reflect.PointerTo(t).Implements(xmlMarshalerType):
+		return true
+	case t.Kind() == reflect.Map:
+		return false
+	case t.Kind() != reflect.Struct || visiting[t]:
+		return true
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if (!f.IsExported() && !f.Anonymous) || f.Tag.Get("xml") == "-" {
+			continue
+		}
+		if !xmlSupports(f.Type, visiting) {
+			return false
+		}
+	}
+	return true
+}
+
+// xmlMarshalerType is the reflect type of xml.Marshaler, whose implementations encode themselves.
+var xmlMarshalerType = reflect.TypeOf((*xml.Marshaler)(nil)).Elem()
+
+// msgpackEncoder encodes results as MessagePack.
+type msgpackEncoder struct{}
+
+// MediaType implements Encoder.
+func (msgpackEncoder) MediaType() string { return "application/msgpack" }
+
+// Encode implements Encoder.
+func (msgpackEncoder) Encode(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// csvEncoder encodes slices of structs as CSV.
+type csvEncoder struct{}
+
+// MediaType implements Encoder.
+func (csvEncoder) MediaType() string { return "text/csv" }
+
+// supports implements typeRestricted.
+func (csvEncoder) supports(t reflect.Type) bool {
+	t = derefType(t)
+	return (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && derefType(t.Elem()).Kind() == reflect.Struct
+}
+
+// Encode implements Encoder.
+func (e csvEncoder) Encode(v interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() || !e.supports(rv.Type()) {
+		return nil, fmt.Errorf("csv: cannot encode %T, only slices of structs", v)
+	}
+
+	columns := csvColumns(derefType(rv.Type().Elem()))
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.name
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	for i := 0; i < rv.Len(); i++ {
+		elem := rv.Index(i)
+		for elem.Kind() == reflect.Ptr {
+			if elem.IsNil() {
+				break
+			}
+			elem = elem.Elem()
+		}
+		record := make([]string, len(columns))
+		if elem.Kind() == reflect.Struct {
+			for j, col := range columns {
+				field, ok := fieldByIndex(elem, col.index)
+				if !ok {
+					continue
+				}
+				cell, err := csvCell(field)
+				if err != nil {
+					return nil, err
+				}
+				record[j] = cell
+			}
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// csvColumn is one CSV column: a struct field and its json name.
+type csvColumn struct {
+	name  string
+	index []int
+}
+
+// csvColumns lists the json-visible fields of struct t, flattening embedded structs.
+func csvColumns(t reflect.Type) []csvColumn {
+	var columns []csvColumn
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		// Like encoding/json, promote the fields of embedded structs, even unexported ones.
+		if f.Anonymous && name == "" && derefType(f.Type).Kind() == reflect.Struct {
+			for _, col := range csvColumns(derefType(f.Type)) {
+				columns = append(columns, csvColumn{name: col.name, index: append([]int{i}, col.index...)})
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		columns = append(columns, csvColumn{name: name, index: []int{i}})
+	}
+	return columns
+}
+
+// csvCell formats a field value: scalars as text, text marshalers (e.g. time.Time) through
+// MarshalText and anything else as JSON.
+func csvCell(v reflect.Value) (string, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		return string(text), err
+	}
+	switch v.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v.Interface()), nil
+	}
+	raw, err := json.Marshal(v.Interface())
+	return string(raw), err
+}
@@ -0,0 +1,192 @@
+package autofiber_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+	autofiber "github.com/vuongtlt13/auto-fiber"
+)
+
+type reportRow struct {
+	ID        int       `json:"id" xml:"id"`
+	Title     string    `json:"title" xml:"title"`
+	CreatedAt time.Time `json:"created_at" xml:"created_at"`
+	Secret    string    `json:"-" xml:"-"`
+}
+
+type auditColumns struct {
+	Author string `json:"author"`
+}
+
+type auditedRow struct {
+	auditColumns
+	ID int `json:"id"`
+}
+
+func encoderApp() *autofiber.AutoFiber {
+	app := autofiber.New(fiber.Config{},
+		autofiber.WithEncoders(
+			autofiber.XMLEncoder(),
+			autofiber.CSVEncoder(),
+			autofiber.MessagePackEncoder(),
+			autofiber.NewEncoder("application/x-test", func(v interface{}) ([]byte, error) {
+				return []byte("test-encoded"), nil
+			}),
+		),
+	)
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	app.Get("/reports", func(c *fiber.Ctx) (interface{}, error) {
+		return []reportRow{{ID: 1, Title: "Q1, draft", CreatedAt: created, Secret: "x"}, {ID: 2, Title: "Q2", CreatedAt: created}}, nil
+	}, autofiber.WithResponseSchema([]reportRow{}))
+	app.Get("/reports/latest", func(c *fiber.Ctx) (interface{}, error) {
+		return reportRow{ID: 2, Title: "Q2", CreatedAt: created}, nil
+	}, autofiber.WithResponseSchema(reportRow{}))
+	app.Get("/reports/export", func(c *fiber.Ctx) (interface{}, error) {
+		return []reportRow{{ID: 1, Title: "Q1", CreatedAt: created}}, nil
+	}, autofiber.WithResponseSchema([]reportRow{}), autofiber.WithProduces("text/csv"))
+	app.Get("/reports/audited", func(c *fiber.Ctx) (interface{}, error) {
+		return []auditedRow{{auditColumns: auditColumns{Author: "ada"}, ID: 1}}, nil
+	})
+	app.Get("/reports/totals", func(c *fiber.Ctx) (interface{}, error) {
+		return map[string]int{"q1": 3}, nil
+	})
+	return app
+}
+
+func getWithAccept(t *testing.T, app *autofiber.AutoFiber, path, accept string) (*http.Response, string) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(body)
+}
+
+func TestEncoders_Negotiation(t *testing.T) {
+	app := encoderApp()
+
+	cases := []struct {
+		name        string
+		path        string
+		accept      string
+		status      int
+		contentType string
+		body        string
+	}{
+		{"json by default", "/reports/latest", "", http.StatusOK, fiber.MIMEApplicationJSON, `{"id":2,"title":"Q2","created_at":"2024-01-02T03:04:05Z"}`},
+		{"wildcard picks json", "/reports/latest", "*/*", http.StatusOK, fiber.MIMEApplicationJSON, ""},
+		{"xml", "/reports/latest", "application/xml", http.StatusOK, fiber.MIMEApplicationXML, "<reportRow><id>2</id><title>Q2</title><created_at>2024-01-02T03:04:05Z</created_at></reportRow>"},
+		{"custom encoder", "/reports/latest", "application/x-test", http.StatusOK, "application/x-test", "test-encoded"},
+		{"csv for slices", "/reports", "text/csv", http.StatusOK, "text/csv", "id,title,created_at\n1,\"Q1, draft\",2024-01-02T03:04:05Z\n2,Q2,2024-01-02T03:04:05Z\n"},
+		{"csv not offered for single objects", "/reports/latest", "text/csv", http.StatusNotAcceptable, "", ""},
+		{"quality values", "/reports/latest", "application/json;q=0.5, application/xml", http.StatusOK, fiber.MIMEApplicationXML, ""},
+		{"unsupported media type", "/reports", "image/png", http.StatusNotAcceptable, "", ""},
+		{"produces restricts encoders", "/reports/export", "application/json", http.StatusNotAcceptable, "", ""},
+		{"produces default", "/reports/export", "", http.StatusOK, "text/csv", ""},
+		{"csv promotes unexported embedded fields", "/reports/audited", "text/csv", http.StatusOK, "text/csv", "author,id\nada,1\n"},
+		{"xml not offered for maps", "/reports/totals", "application/xml", http.StatusNotAcceptable, "", ""},
+		{"maps fall back to json", "/reports/totals", "application/xml, application/json;q=0.5", http.StatusOK, fiber.MIMEApplicationJSON, `{"q1":3}`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, body := getWithAccept(t, app, tc.path, tc.accept)
+			assert.Equal(t, tc.status, resp.StatusCode)
+			if tc.contentType != "" {
+				assert.Equal(t, tc.contentType, resp.Header.Get("Content-Type"))
+			}
+			if tc.body != "" {
+				assert.Equal(t, tc.body, body)
+			}
+		})
+	}
+}
+
+func TestEncoders_NotAcceptableBeforeHandler(t *testing.T) {
+	calls := 0
+	app := encoderApp()
+	app.Post("/reports", func(c *fiber.Ctx) (interface{}, error) {
+		calls++
+		return reportRow{ID: 3}, nil
+	}, autofiber.WithResponseSchema(reportRow{}))
+	app.Post("/reports/import", func(c *fiber.Ctx) ([]reportRow, error) {
+		calls++
+		return nil, nil
+	}, autofiber.WithProduces("text/csv"))
+	app.Post("/reports/any", func(c *fiber.Ctx) (interface{}, error) {
+		calls++
+		return []reportRow{}, nil
+	})
+
+	post := func(path, accept string) int {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.Header.Set("Accept", accept)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusNotAcceptable, post("/reports", "text/html"))
+	assert.Equal(t, http.StatusNotAcceptable, post("/reports", "text/csv"), "csv cannot encode the schema")
+	assert.Equal(t, http.StatusNotAcceptable, post("/reports/import", "application/json"))
+	assert.Equal(t, http.StatusNotAcceptable, post("/reports/any", "text/html"))
+	assert.Zero(t, calls, "the handler does not run for unacceptable requests")
+
+	assert.Equal(t, http.StatusOK, post("/reports", "application/xml"))
+	assert.Equal(t, http.StatusOK, post("/reports/import", "text/csv"))
+	assert.Equal(t, http.StatusOK, post("/reports/any", "text/csv"))
+	assert.Equal(t, 3, calls)
+}
+
+func TestEncoders_MessagePack(t *testing.T) {
+	resp, body := getWithAccept(t, encoderApp(), "/reports/latest", "application/msgpack")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/msgpack", resp.Header.Get("Content-Type"))
+
+	var decoded map[string]interface{}
+	require.NoError(t, msgpack.Unmarshal([]byte(body), &decoded))
+	assert.Equal(t, "Q2", decoded["title"], "keys follow json tags")
+	assert.EqualValues(t, 2, decoded["id"])
+	assert.NotContains(t, decoded, "Secret")
+	assert.Contains(t, decoded, "created_at")
+}
+
+func TestEncoders_NotAcceptableError(t *testing.T) {
+	app := encoderApp()
+
+	resp, body := getWithAccept(t, app, "/reports", "image/png")
+	assert.Equal(t, http.StatusNotAcceptable, resp.StatusCode)
+
+	var httpErr autofiber.HTTPError
+	require.NoError(t, json.Unmarshal([]byte(body), &httpErr))
+	assert.Equal(t, autofiber.CodeNotAcceptable, httpErr.Code)
+}
+
+func TestEncoders_Docs(t *testing.T) {
+	spec := encoderApp().GetOpenAPISpec()
+
+	content := spec.Paths["/reports"].Get.Responses["200"].Content
+	assert.Len(t, content, 5)
+	for _, mediaType := range []string{fiber.MIMEApplicationJSON, fiber.MIMEApplicationXML, "text/csv", "application/msgpack", "application/x-test"} {
+		assert.Contains(t, content, mediaType)
+	}
+
+	content = spec.Paths["/reports/latest"].Get.Responses["200"].Content
+	assert.NotContains(t, content, "text/csv")
+	assert.Contains(t, content, fiber.MIMEApplicationXML)
+
+	content = spec.Paths["/reports/export"].Get.Responses["200"].Content
+	assert.Len(t, content, 1)
+	assert.Contains(t, content, "text/csv")
+}
@@ -0,0 +1,125 @@
+// Package autofiber provides an app-wide response envelope carrying payloads, errors and metadata.
+package autofiber
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// EnvelopeMetaFunc returns the metadata added to every enveloped response, such as the request
+// ID or API version. It may return nil.
+type EnvelopeMetaFunc func(c *fiber.Ctx) map[string]interface{}
+
+// Envelope is the body of JSON responses when WithEnvelope is enabled.
+type Envelope struct {
+	Data  interface{}            `json:"data"`
+	Error *EnvelopeError         `json:"error,omitempty"`
+	Meta  map[string]interface{} `json:"meta,omitempty"`
+}
+
+// EnvelopeError describes a failed request inside an Envelope.
+type EnvelopeError struct {
+	Message   string             `json:"message"`
+	Code      string             `json:"code,omitempty"`
+	Details   []FieldErrorDetail `json:"details,omitempty"`
+	RequestID string             `json:"request_id,omitempty"`
+}
+
+// WithEnvelope wraps JSON response bodies as {"data": ..., "meta": {...}} and the errors AutoFiber
+// renders itself (validation errors, HTTPErrors, the missing-Authorization 401, ...) as
+// {"data": null, "error": {...}, "meta": {...}}. meta, which may be nil, supplies metadata for every
+// response; a handler adds its own through Response.Meta. The generated response schemas show the
+// envelope.
+//
+// Bodies in other media types (see WithEncoders), streams, files and no-content responses are sent
+// without envelope, as are problem details and the output of custom error handlers.
+//
+// Example:
+//
+//	app := autofiber.New(fiber.Config{},
+//	    autofiber.WithEnvelope(func(c *fiber.Ctx) map[string]interface{} {
+//	        return map[string]interface{}{"request_id": c.GetRespHeader(fiber.HeaderXRequestID)}
+//	    }),
+//	)
+func WithEnvelope(meta EnvelopeMetaFunc) AutoFiberOption {
+	return func(af *AutoFiber) {
+		af.envelope = &envelopeConfig{meta: meta}
+		af.docsGenerator.envelope = true
+	}
+}
+
+// envelopeConfig is the app's envelope setting.
+type envelopeConfig struct {
+	meta EnvelopeMetaFunc
+}
+
+// metaFor merges the app-level metadata with the handler's, which wins on conflicts.
+func (e *envelopeConfig) metaFor(c *fiber.Ctx, extra map[string]interface{}) map[string]interface{} {
+	var meta map[string]interface{}
+	if e.meta != nil {
+		meta = e.meta(c)
+	}
+	if len(extra) == 0 {
+		return meta
+	}
+	merged := make(map[string]interface{}, len(meta)+len(extra))
+	for k, v := range meta {
+		merged[k] = v
+	}
+	for k, v := range extra {
+		merged[k] = v
+	}
+	return merged
+}
+
+// wrap returns the envelope for a successful response body.
+func (e *envelopeConfig) wrap(c *fiber.Ctx, data interface{}, meta map[string]interface{}) Envelope {
+	return Envelope{Data: data, Meta: e.metaFor(c, meta)}
+}
+
+// writeError responds with the envelope for err, keeping the status the error carries.
+func (e *envelopeConfig) writeError(c *fiber.Ctx, err error) error {
+	problem := NewProblemDetails(c, err)
+	return c.Status(problem.Status).JSON(Envelope{
+		Error: &EnvelopeError{
+			Message:   problem.Detail,
+			Code:      problem.Code,
+			Details:   problem.Errors,
+			RequestID: problem.RequestID,
+		},
+		Meta: e.metaFor(c, nil),
+	})
+}
+
+// envelopeSchema is the OpenAPI schema of a successful Envelope around data.
+func envelopeSchema(data *OpenAPISchema) *OpenAPISchema {
+	return &OpenAPISchema{
+		Type:     "object",
+		Required: []string{"data"},
+		Properties: map[string]OpenAPISchema{
+			"data": *data,
+			"meta": {Type: "object"},
+		},
+	}
+}
+
+// envelopeErrorSchema is the OpenAPI schema of an Envelope carrying an error.
+func envelopeErrorSchema() *OpenAPISchema {
+	return &OpenAPISchema{
+		Type:     "object",
+		Required: []string{"data", "error"},
+		Properties: map[string]OpenAPISchema{
+			"data": {Type: "object", Nullable: true},
+			"error": {
+				Type:     "object",
+				Required: []string{"message"},
+				Properties: map[string]OpenAPISchema{
+					"message":    {Type: "string"},
+					"code":       {Type: "string"},
+					"details":    {Type: "array", Items: &OpenAPISchema{Type: "object"}},
+					"request_id": {Type: "string"},
+				},
+			},
+			"meta": {Type: "object"},
+		},
+	}
+}
@@ -0,0 +1,104 @@
+package autofiber_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autofiber "github.com/vuongtlt13/auto-fiber"
+)
+
+type envelopeUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name" validate:"required"`
+}
+
+func envelopeApp() *autofiber.AutoFiber {
+	app := autofiber.New(fiber.Config{}, autofiber.WithEnvelope(func(c *fiber.Ctx) map[string]interface{} {
+		return map[string]interface{}{"version": "v1"}
+	}))
+	app.Get("/users/1", func(c *fiber.Ctx) (interface{}, error) {
+		return envelopeUser{ID: 1, Name: "Ada"}, nil
+	}, autofiber.WithResponseSchema(envelopeUser{}))
+	app.Get("/users", func(c *fiber.Ctx) (interface{}, error) {
+		return autofiber.Response[[]envelopeUser]{
+			Body: []envelopeUser{{ID: 1, Name: "Ada"}},
+			Meta: map[string]interface{}{"total": 1},
+		}, nil
+	})
+	app.Post("/users", func(c *fiber.Ctx, req *envelopeUser) (interface{}, error) {
+		return req, nil
+	}, autofiber.WithRequestSchema(envelopeUser{}))
+	app.Get("/missing", func(c *fiber.Ctx) (interface{}, error) {
+		return nil, autofiber.NotFound("user not found").WithCode("user_not_found")
+	}, autofiber.WithErrors(fiber.StatusNotFound))
+	return app
+}
+
+func decodeEnvelope(t *testing.T, resp *http.Response) map[string]interface{} {
+	t.Helper()
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return body
+}
+
+func TestEnvelope_Success(t *testing.T) {
+	app := envelopeApp()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/users/1", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, map[string]interface{}{
+		"data": map[string]interface{}{"id": float64(1), "name": "Ada"},
+		"meta": map[string]interface{}{"version": "v1"},
+	}, decodeEnvelope(t, resp))
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/users", nil))
+	require.NoError(t, err)
+	body := decodeEnvelope(t, resp)
+	assert.Len(t, body["data"], 1)
+	assert.Equal(t, map[string]interface{}{"version": "v1", "total": float64(1)}, body["meta"])
+}
+
+func TestEnvelope_Errors(t *testing.T) {
+	app := envelopeApp()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/missing", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	body := decodeEnvelope(t, resp)
+	assert.Nil(t, body["data"])
+	assert.Equal(t, map[string]interface{}{"message": "user not found", "code": "user_not_found"}, body["error"])
+	assert.Equal(t, map[string]interface{}{"version": "v1"}, body["meta"])
+
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	body = decodeEnvelope(t, resp)
+	envErr := body["error"].(map[string]interface{})
+	assert.Equal(t, autofiber.CodeValidationFailed, envErr["code"])
+	assert.Len(t, envErr["details"], 1)
+}
+
+func TestEnvelope_Docs(t *testing.T) {
+	spec := envelopeApp().GetOpenAPISpec()
+
+	ok := spec.Paths["/users/1"].Get.Responses["200"].Content["application/json"].Schema
+	require.NotNil(t, ok)
+	assert.Equal(t, "#/components/schemas/envelopeUser", ok.Properties["data"].Ref)
+	assert.Contains(t, ok.Properties, "meta")
+
+	notFound := spec.Paths["/missing"].Get.Responses["404"].Content["application/json"].Schema
+	require.NotNil(t, notFound)
+	assert.Contains(t, notFound.Properties["error"].Properties, "message")
+	assert.True(t, notFound.Properties["data"].Nullable)
+
+	assert.Contains(t, spec.Paths["/users/1"].Get.Responses["400"].Content["application/json"].Schema.Properties, "error")
+}
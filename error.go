@@ -1,22 +1,43 @@
 package autofiber
 
-// FieldErrorDetail represents a single field validation error
+import (
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldErrorDetail represents a single field validation error.
+// Field is the name the client sent (json name or parse key, e.g. "address.city" or "page"),
+// Pointer is the RFC 6901 JSON pointer into the request body for body fields (e.g. "/address/city")
+// and Source tells where the field was read from (body, query, path, header, cookie or form).
 type FieldErrorDetail struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
 	Tag     string `json:"tag,omitempty"`
+	Pointer string `json:"pointer,omitempty"`
+	Source  string `json:"source,omitempty"`
+	Code    string `json:"code,omitempty"` // machine-readable code, e.g. CodeMissingField for parse errors
 }
 
 // ValidationResponseError is used for response validation errors
 type ValidationResponseError struct {
 	Message string             `json:"error"`
+	Code    string             `json:"code,omitempty"` // machine-readable code, e.g. CodeResponseValidationFailed
 	Details []FieldErrorDetail `json:"details,omitempty"`
+	// Status is the HTTP status of WithProblemDetails and WithEnvelope error responses; 500 when
+	// zero. Otherwise the error is passed as is to WithErrorHandler or Fiber's error handler.
+	Status int `json:"-"`
 }
 
 // ValidationRequestError is used for request validation errors
 type ValidationRequestError struct {
 	Message string             `json:"error"`
+	Code    string             `json:"code,omitempty"` // machine-readable code, e.g. CodeValidationFailed
 	Details []FieldErrorDetail `json:"details,omitempty"`
+	// Status is the HTTP status of WithProblemDetails and WithEnvelope error responses: 400 for
+	// unparsable requests, 422 for failed validation; 400 when zero. Otherwise the error is passed
+	// as is to WithErrorHandler or Fiber's error handler, which choose the status.
+	Status int `json:"-"`
 }
 
 // Error implements the error interface for ValidationResponseError
@@ -28,3 +49,38 @@ func (e *ValidationResponseError) Error() string {
 func (e *ValidationRequestError) Error() string {
 	return e.Message
 }
+
+// FieldErrors is returned from RequestValidator.Validate to report one or more field-scoped failures.
+type FieldErrors []FieldErrorDetail
+
+// Error implements the error interface for FieldErrors
+func (e FieldErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, d := range e {
+		msgs[i] = d.Field + ": " + d.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// requestValidationError combines tag validation failures with the failure of a schema's
+// Validate hook. Both remain reachable through errors.As.
+type requestValidationError struct {
+	tags validator.ValidationErrors
+	hook error
+}
+
+// Error implements the error interface for requestValidationError
+func (e *requestValidationError) Error() string {
+	if e.tags == nil {
+		return e.hook.Error()
+	}
+	return e.tags.Error() + "; " + e.hook.Error()
+}
+
+// Unwrap returns the tag validation errors (when present) and the hook error.
+func (e *requestValidationError) Unwrap() []error {
+	if e.tags == nil {
+		return []error{e.hook}
+	}
+	return []error{e.tags, e.hook}
+}
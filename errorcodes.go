@@ -0,0 +1,113 @@
+// Package autofiber provides stable machine-readable error codes and a catalog describing them.
+package autofiber
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Built-in error codes set by AutoFiber on the errors it generates.
+const (
+	// CodeInvalidRequest marks a request that could not be parsed (ValidationRequestError, 400).
+	CodeInvalidRequest = "invalid_request"
+	// CodeValidationFailed marks a request that failed validation (ValidationRequestError, 422).
+	CodeValidationFailed = "validation_failed"
+	// CodeResponseValidationFailed marks a response that did not match its schema (ValidationResponseError, 500).
+	CodeResponseValidationFailed = "response_validation_failed"
+	// CodeInternalError marks an unexpected server failure such as a recovered handler panic (HTTPError, 500).
+	CodeInternalError = "internal_error"
+	// CodeNotAcceptable marks a request whose Accept header matches none of the route's media types (HTTPError, 406).
+	CodeNotAcceptable = "not_acceptable"
+	// CodeValidationTimeout marks a request whose asynchronous validators did not finish in time (HTTPError, 504).
+	CodeValidationTimeout = "validation_timeout"
+	// CodeMissingField marks a required parameter that was not sent (ParseError).
+	CodeMissingField = "missing_field"
+	// CodeInvalidValue marks a parameter that could not be converted to its type (ParseError).
+	CodeInvalidValue = "invalid_value"
+	// CodeInvalidBody marks a missing or malformed request body (ParseError).
+	CodeInvalidBody = "invalid_body"
+)
+
+// ErrorCode describes an error code clients can match on. Status is the HTTP status responses
+// carrying the code use; it is zero for codes that only appear on field details.
+type ErrorCode struct {
+	Code        string `json:"code"`
+	Status      int    `json:"status,omitempty"`
+	Description string `json:"description"`
+}
+
+// builtinErrorCodes are registered in every app's catalog.
+var builtinErrorCodes = []ErrorCode{
+	{Code: CodeInvalidRequest, Status: fiber.StatusBadRequest, Description: "The request could not be parsed."},
+	{Code: CodeValidationFailed, Status: fiber.StatusUnprocessableEntity, Description: "The request failed validation; see the field details."},
+	{Code: CodeResponseValidationFailed, Status: fiber.StatusInternalServerError, Description: "The response did not match its documented schema."},
+	{Code: CodeInternalError, Status: fiber.StatusInternalServerError, Description: "An unexpected server error occurred; quote the request ID when reporting it."},
+	{Code: CodeNotAcceptable, Status: fiber.StatusNotAcceptable, Description: "The response cannot be produced in any media type the Accept header allows."},
+	{Code: CodeValidationTimeout, Status: fiber.StatusGatewayTimeout, Description: "Asynchronous validation of the request did not finish in time."},
+	{Code: CodeMissingField, Description: "A required parameter is missing."},
+	{Code: CodeInvalidValue, Description: "A parameter could not be converted to its type."},
+	{Code: CodeInvalidBody, Description: "The request body is missing or malformed."},
+}
+
+// RegisterErrorCodes adds application error codes (e.g. those used with HTTPError.WithCode) to the
+// app's catalog. Registering an existing code replaces its entry.
+//
+// Example:
+//
+//	app.RegisterErrorCodes(
+//	    autofiber.ErrorCode{Code: "user_not_found", Status: 404, Description: "No user has the given ID."},
+//	    autofiber.ErrorCode{Code: "email_taken", Status: 409, Description: "The email is already registered."},
+//	)
+func (af *AutoFiber) RegisterErrorCodes(codes ...ErrorCode) {
+	af.errorCatalog.register(codes...)
+}
+
+// ErrorCatalog returns every registered error code, sorted by code.
+func (af *AutoFiber) ErrorCatalog() []ErrorCode {
+	return af.errorCatalog.list()
+}
+
+// ServeErrorCatalog serves the error catalog as JSON at path and adds it to the OpenAPI
+// specification as the x-error-codes extension.
+func (af *AutoFiber) ServeErrorCatalog(path string) {
+	af.docsGenerator.errorCatalog = af.errorCatalog
+	af.App.Get(path, func(c *fiber.Ctx) error {
+		return c.JSON(af.ErrorCatalog())
+	})
+}
+
+// errorCatalog is a concurrency-safe registry of error codes.
+type errorCatalog struct {
+	mu    sync.RWMutex
+	codes map[string]ErrorCode
+}
+
+// newErrorCatalog creates a catalog holding the built-in codes.
+func newErrorCatalog() *errorCatalog {
+	catalog := &errorCatalog{codes: make(map[string]ErrorCode)}
+	catalog.register(builtinErrorCodes...)
+	return catalog
+}
+
+// register adds or replaces codes.
+func (e *errorCatalog) register(codes ...ErrorCode) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, code := range codes {
+		e.codes[code.Code] = code
+	}
+}
+
+// list returns the registered codes sorted by code.
+func (e *errorCatalog) list() []ErrorCode {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	codes := make([]ErrorCode, 0, len(e.codes))
+	for _, code := range e.codes {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i].Code < codes[j].Code })
+	return codes
+}
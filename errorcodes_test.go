@@ -0,0 +1,91 @@
+package autofiber_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	autofiber "github.com/vuongtlt13/auto-fiber"
+)
+
+func TestErrorCatalog(t *testing.T) {
+	app := autofiber.New(fiber.Config{})
+	app.RegisterErrorCodes(autofiber.ErrorCode{Code: "user_not_found", Status: 404, Description: "No user has the given ID."})
+
+	var codes []string
+	for _, code := range app.ErrorCatalog() {
+		codes = append(codes, code.Code)
+	}
+	assert.Equal(t, []string{
+		autofiber.CodeInternalError,
+		autofiber.CodeInvalidBody,
+		autofiber.CodeInvalidRequest,
+		autofiber.CodeInvalidValue,
+		autofiber.CodeMissingField,
+		autofiber.CodeNotAcceptable,
+		autofiber.CodeResponseValidationFailed,
+		"user_not_found",
+		autofiber.CodeValidationFailed,
+		autofiber.CodeValidationTimeout,
+	}, codes)
+
+	// Without ServeErrorCatalog the spec has no extension.
+	assert.Empty(t, app.GetOpenAPISpec().ErrorCodes)
+}
+
+func TestServeErrorCatalog(t *testing.T) {
+	app := autofiber.New(fiber.Config{})
+	app.ServeErrorCatalog("/errors")
+	app.RegisterErrorCodes(autofiber.ErrorCode{Code: "email_taken", Status: 409, Description: "The email is already registered."})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/errors", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var served []autofiber.ErrorCode
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&served))
+	assert.Contains(t, served, autofiber.ErrorCode{Code: "email_taken", Status: 409, Description: "The email is already registered."})
+
+	raw, err := app.GetOpenAPIJSON()
+	assert.NoError(t, err)
+	var spec map[string]interface{}
+	assert.NoError(t, json.Unmarshal(raw, &spec))
+	assert.Len(t, spec["x-error-codes"], len(served))
+}
+
+func TestErrorCodes_OnGeneratedErrors(t *testing.T) {
+	app := newTestApp()
+	type Req struct {
+		Page  int    `parse:"query:page,required"`
+		Email string `json:"email" validate:"required"`
+	}
+	app.Post("/items", func(c *fiber.Ctx, req *Req) (interface{}, error) {
+		return req, nil
+	}, autofiber.WithRequestSchema(Req{}))
+
+	post := func(target, body string) autofiber.ValidationRequestError {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		var payload autofiber.ValidationRequestError
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&payload))
+		return payload
+	}
+
+	payload := post("/items", `{"email":"a@b.co"}`)
+	assert.Equal(t, autofiber.CodeInvalidRequest, payload.Code)
+	assert.Equal(t, autofiber.CodeMissingField, payload.Details[0].Code)
+
+	payload = post("/items?page=x", `{"email":"a@b.co"}`)
+	assert.Equal(t, autofiber.CodeInvalidValue, payload.Details[0].Code)
+
+	payload = post("/items?page=1", `{"email":`)
+	assert.Equal(t, autofiber.CodeInvalidBody, payload.Details[0].Code)
+
+	payload = post("/items?page=1", `{}`)
+	assert.Equal(t, autofiber.CodeValidationFailed, payload.Code)
+}
@@ -0,0 +1,280 @@
+// Package autofiber provides sparse fieldsets (?fields=) and expansions (?expand=) for route responses.
+package autofiber
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+const (
+	// fieldsParam is the query parameter selecting response fields.
+	fieldsParam = "fields"
+	// expandParam is the query parameter requesting expansions.
+	expandParam = "expand"
+)
+
+// Expand holds the expansions requested with ?expand=. A request schema field of this type is
+// filled by AutoFiber on routes registered with WithExpand.
+//
+// Example:
+//
+//	type GetUserRequest struct {
+//	    ID     string           `parse:"path:id" validate:"required"`
+//	    Expand autofiber.Expand `json:"-"`
+//	}
+//
+//	func getUser(c *fiber.Ctx, req *GetUserRequest) (*User, error) {
+//	    user := users.Get(req.ID)
+//	    if req.Expand.Has("organization") {
+//	        user.Organization = orgs.Get(user.OrgID)
+//	    }
+//	    return user, nil
+//	}
+type Expand []string
+
+// Has reports whether name was requested.
+func (e Expand) Has(name string) bool {
+	for _, requested := range e {
+		if requested == name {
+			return true
+		}
+	}
+	return false
+}
+
+// expandType is the reflect type of Expand.
+var expandType = reflect.TypeOf(Expand(nil))
+
+// RequestedExpand returns the expansions accepted for the current request.
+func RequestedExpand(c *fiber.Ctx) Expand {
+	expand, _ := c.Locals(expandParam).(Expand)
+	return expand
+}
+
+// splitList splits a comma-separated query value, dropping blanks.
+func splitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// selectFields validates ?fields= and ?expand= for routes with WithFields or WithExpand, storing
+// the selected fields and expansions in Locals and the expansions in req's Expand fields.
+func selectFields(c *fiber.Ctx, opts *RouteOptions, req interface{}) error {
+	if opts.Fields {
+		fields := splitList(c.Query(fieldsParam))
+		if schema := opts.responseSchemaFor(opts.successStatus()); schema != nil && len(fields) > 0 {
+			allowed := fieldPaths(reflect.TypeOf(schema))
+			for _, field := range fields {
+				if !allowed[field] {
+					return selectionError(fieldsParam, fmt.Sprintf("unknown field %q", field))
+				}
+			}
+		}
+		c.Locals(fieldsParam, fields)
+	}
+
+	if len(opts.Expand) > 0 {
+		expand := Expand(splitList(c.Query(expandParam)))
+		for _, name := range expand {
+			if !Expand(opts.Expand).Has(name) {
+				return selectionError(expandParam, fmt.Sprintf("unknown expansion %q; allowed: %s", name, strings.Join(opts.Expand, ", ")))
+			}
+		}
+		c.Locals(expandParam, expand)
+		if req != nil {
+			setExpandFields(reflect.ValueOf(req), expand)
+		}
+	}
+	return nil
+}
+
+// selectionError reports an invalid fields or expand parameter.
+func selectionError(param, message string) *ValidationRequestError {
+	return &ValidationRequestError{
+		Message: "Invalid request",
+		Code:    CodeInvalidRequest,
+		Details: []FieldErrorDetail{{Field: param, Message: message, Source: string(Query), Code: CodeInvalidValue}},
+		Status:  fiber.StatusBadRequest,
+	}
+}
+
+// setExpandFields stores expand in the top-level Expand fields of the struct v points to.
+func setExpandFields(v reflect.Value, expand Expand) {
+	v = reflect.Indirect(v)
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if f := v.Field(i); f.Type() == expandType && f.CanSet() {
+			f.Set(reflect.ValueOf(expand))
+		}
+	}
+}
+
+// projectJSON encodes data with the app's JSON encoder, so json tags and custom marshalers apply,
+// and keeps only the selected fields. Values are kept as raw JSON, so they are sent byte-for-byte
+// (numbers keep their full precision).
+func (af *AutoFiber) projectJSON(data interface{}, fields []string) (interface{}, error) {
+	config := af.App.Config()
+	raw, err := config.JSONEncoder(data)
+	if err != nil {
+		return nil, err
+	}
+	tree := fieldTree{}
+	for _, field := range fields {
+		tree.add(strings.Split(field, "."))
+	}
+	return tree.project(raw, config.JSONDecoder), nil
+}
+
+// fieldTree is a set of selected paths; a nil subtree selects the whole value.
+type fieldTree map[string]fieldTree
+
+// add selects path.
+func (t fieldTree) add(path []string) {
+	sub, exists := t[path[0]]
+	if len(path) == 1 {
+		t[path[0]] = nil
+		return
+	}
+	if exists && sub == nil {
+		return // already selected whole
+	}
+	if sub == nil {
+		sub = fieldTree{}
+		t[path[0]] = sub
+	}
+	sub.add(path[1:])
+}
+
+// project applies the selection to the raw JSON objects, and to each element of arrays, leaving
+// other values untouched.
+func (t fieldTree) project(raw json.RawMessage, decode utils.JSONUnmarshal) interface{} {
+	var object map[string]json.RawMessage
+	if decode(raw, &object) == nil && object != nil {
+		projected := make(map[string]interface{}, len(t))
+		for name, sub := range t {
+			field, ok := object[name]
+			if !ok {
+				continue
+			}
+			if sub == nil {
+				projected[name] = field
+			} else {
+				projected[name] = sub.project(field, decode)
+			}
+		}
+		return projected
+	}
+	var array []json.RawMessage
+	if decode(raw, &array) == nil && array != nil {
+		projected := make([]interface{}, len(array))
+		for i, elem := range array {
+			projected[i] = t.project(elem, decode)
+		}
+		return projected
+	}
+	return raw
+}
+
+// fieldPaths returns the selectable json paths of t: every field, and every field of nested
+// objects (including array elements) as a dotted path.
+func fieldPaths(t reflect.Type) map[string]bool {
+	paths := map[string]bool{}
+	collectFieldPaths(t, "", paths, map[reflect.Type]bool{})
+	return paths
+}
+
+// sortedFieldPaths returns the paths of fieldPaths in order, for documentation.
+func sortedFieldPaths(t reflect.Type) []string {
+	paths := make([]string, 0)
+	for path := range fieldPaths(t) {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// collectFieldPaths adds the json paths of struct fields reachable from t under prefix.
+func collectFieldPaths(t reflect.Type, prefix string, paths map[string]bool, visiting map[reflect.Type]bool) {
+	t = derefType(t)
+	for t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = derefType(t.Elem())
+	}
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) || visiting[t] {
+		return
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		// Like encoding/json, promote the fields of embedded structs, even unexported ones.
+		if f.Anonymous && name == "" && derefType(f.Type).Kind() == reflect.Struct {
+			collectFieldPaths(f.Type, prefix, paths, visiting)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		path := prefix + name
+		paths[path] = true
+		collectFieldPaths(f.Type, path+".", paths, visiting)
+	}
+}
+
+// appendSelectionParameters documents the fields and expand query parameters of a route as
+// comma-separated lists, enumerating the allowed values, unless the request schema declares them.
+func appendSelectionParameters(parameters []OpenAPIParameter, opts *RouteOptions) []OpenAPIParameter {
+	add := func(name, description string, allowed []string) {
+		for _, param := range parameters {
+			if param.In == "query" && param.Name == name {
+				return
+			}
+		}
+		items := &OpenAPISchema{Type: "string"}
+		for _, value := range allowed {
+			items.Enum = append(items.Enum, value)
+		}
+		explode := false
+		parameters = append(parameters, OpenAPIParameter{
+			Name:        name,
+			In:          "query",
+			Description: description,
+			Schema:      &OpenAPISchema{Type: "array", Items: items},
+			Style:       "form",
+			Explode:     &explode,
+		})
+	}
+
+	if opts.Fields {
+		var allowed []string
+		if schema := opts.responseSchemaFor(opts.successStatus()); schema != nil {
+			allowed = sortedFieldPaths(reflect.TypeOf(schema))
+		}
+		add(fieldsParam, "Comma-separated response fields to return; dotted paths select nested fields", allowed)
+	}
+	if len(opts.Expand) > 0 {
+		add(expandParam, "Comma-separated related resources to expand", opts.Expand)
+	}
+	return parameters
+}
@@ -0,0 +1,170 @@
+package autofiber_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autofiber "github.com/vuongtlt13/auto-fiber"
+)
+
+type fieldsOrganization struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type fieldsAudit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type fieldsUser struct {
+	fieldsAudit
+	ID           int                  `json:"id"`
+	Name         string               `json:"name"`
+	Email        string               `json:"email"`
+	Organization *fieldsOrganization  `json:"organization,omitempty"`
+	Teams        []fieldsOrganization `json:"teams"`
+	Password     string               `json:"-"`
+}
+
+type fieldsUserRequest struct {
+	ID     int              `parse:"path:id" validate:"required"`
+	Expand autofiber.Expand `json:"-"`
+}
+
+func fieldsApp() *autofiber.AutoFiber {
+	app := newTestApp()
+	app.Get("/users/:id", func(c *fiber.Ctx, req *fieldsUserRequest) (interface{}, error) {
+		user := fieldsUser{
+			fieldsAudit: fieldsAudit{CreatedBy: "admin"},
+			ID:          req.ID,
+			Name:        "Ada",
+			Email:       "ada@example.com",
+			Teams:       []fieldsOrganization{{ID: 1, Name: "core"}, {ID: 2, Name: "docs"}},
+			Password:    "secret",
+		}
+		if req.Expand.Has("organization") {
+			user.Organization = &fieldsOrganization{ID: 7, Name: "Acme"}
+		}
+		return user, nil
+	},
+		autofiber.WithRequestSchema(fieldsUserRequest{}),
+		autofiber.WithResponseSchema(fieldsUser{}),
+		autofiber.WithFields(),
+		autofiber.WithExpand("organization"),
+	)
+	app.Get("/teams", func(c *fiber.Ctx) (interface{}, error) {
+		return map[string]interface{}{
+			"expanded": autofiber.RequestedExpand(c),
+			"teams":    []fieldsOrganization{{ID: 1, Name: "core"}},
+		}, nil
+	}, autofiber.WithFields(), autofiber.WithExpand("members"))
+	return app
+}
+
+func getJSON(t *testing.T, app *autofiber.AutoFiber, path string) (int, map[string]interface{}) {
+	t.Helper()
+	resp, body := getWithAccept(t, app, path, "")
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(body), &payload), body)
+	return resp.StatusCode, payload
+}
+
+func TestFields_Projection(t *testing.T) {
+	app := fieldsApp()
+
+	status, body := getJSON(t, app, "/users/1")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, body, 5, "no projection without ?fields=")
+
+	status, body = getJSON(t, app, "/users/1?fields=id,name")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]interface{}{"id": float64(1), "name": "Ada"}, body)
+
+	_, body = getJSON(t, app, "/users/1?fields=teams.name,created_by")
+	assert.Equal(t, map[string]interface{}{
+		"created_by": "admin",
+		"teams":      []interface{}{map[string]interface{}{"name": "core"}, map[string]interface{}{"name": "docs"}},
+	}, body)
+
+	_, body = getJSON(t, app, "/users/1?fields=teams,teams.name")
+	assert.Len(t, body["teams"].([]interface{})[0], 2, "selecting an object keeps it whole")
+
+	// Untyped routes accept any path.
+	_, body = getJSON(t, app, "/teams?fields=teams.id,unknown")
+	assert.Equal(t, map[string]interface{}{"teams": []interface{}{map[string]interface{}{"id": float64(1)}}}, body)
+}
+
+func TestFields_ProjectionKeepsNumbers(t *testing.T) {
+	app := newTestApp()
+	app.Get("/ids", func(c *fiber.Ctx) (interface{}, error) {
+		return map[string]interface{}{
+			"id":    int64(9007199254740993),
+			"price": json.Number("1.10"),
+			"items": []map[string]int64{{"id": 9007199254740995, "qty": 1}},
+		}, nil
+	}, autofiber.WithFields())
+
+	resp, body := getWithAccept(t, app, "/ids?fields=id,price,items.id", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"id":9007199254740993,"price":1.10,"items":[{"id":9007199254740995}]}`, body)
+	assert.Contains(t, body, "9007199254740993")
+	assert.Contains(t, body, "1.10")
+}
+
+func TestFields_Expand(t *testing.T) {
+	app := fieldsApp()
+
+	_, body := getJSON(t, app, "/users/1?expand=organization&fields=organization.name")
+	assert.Equal(t, map[string]interface{}{"organization": map[string]interface{}{"name": "Acme"}}, body)
+
+	_, body = getJSON(t, app, "/users/1")
+	assert.NotContains(t, body, "organization")
+
+	_, body = getJSON(t, app, "/teams?expand=members")
+	assert.Equal(t, []interface{}{"members"}, body["expanded"])
+}
+
+func TestFields_InvalidSelection(t *testing.T) {
+	app := fieldsApp()
+
+	for _, path := range []string{"/users/1?fields=id,password", "/users/1?fields=teams.owner", "/users/1?expand=manager"} {
+		status, body := getJSON(t, app, path)
+		assert.Equal(t, http.StatusBadRequest, status, path)
+		assert.Equal(t, autofiber.CodeInvalidRequest, body["code"], path)
+	}
+}
+
+func TestFields_Docs(t *testing.T) {
+	spec := fieldsApp().GetOpenAPISpec()
+
+	params := map[string]autofiber.OpenAPIParameter{}
+	for _, param := range spec.Paths["/users/{id}"].Get.Parameters {
+		params[param.Name] = param
+	}
+	require.Contains(t, params, "fields")
+	require.Contains(t, params, "expand")
+	assert.NotContains(t, params, "Expand")
+
+	fields := params["fields"]
+	assert.Equal(t, "query", fields.In)
+	assert.Equal(t, "array", fields.Schema.Type)
+	assert.Equal(t, "form", fields.Style)
+	require.NotNil(t, fields.Explode)
+	assert.False(t, *fields.Explode)
+	assert.Equal(t, []interface{}{
+		"created_by", "email", "id", "name", "organization", "organization.id", "organization.name",
+		"teams", "teams.id", "teams.name",
+	}, fields.Schema.Items.Enum)
+	assert.Equal(t, []interface{}{"organization"}, params["expand"].Schema.Items.Enum)
+
+	params = map[string]autofiber.OpenAPIParameter{}
+	for _, param := range spec.Paths["/teams"].Get.Parameters {
+		params[param.Name] = param
+	}
+	assert.Nil(t, params["fields"].Schema.Items.Enum)
+	assert.Equal(t, []interface{}{"members"}, params["expand"].Schema.Items.Enum)
+}
@@ -0,0 +1,250 @@
+// Package autofiber provides in-memory and streamed file responses with HTTP Range support.
+package autofiber
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"reflect"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// BytesFile is a FileResponse sending content held in memory, such as a generated PDF or image.
+// Range requests are answered with 206 Partial Content.
+//
+// Usage in handler:
+//
+//	func (h *Handler) Invoice(c *fiber.Ctx, req *InvoiceRequest) (interface{}, error) {
+//	    pdf, err := h.invoices.Render(req.ID)
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return autofiber.BytesFile{Data: pdf, ContentType: "application/pdf", FileName: "invoice.pdf"}, nil
+//	}
+type BytesFile struct {
+	// Data is the file content.
+	Data []byte
+	// ContentType is the media type of Data; application/octet-stream when empty.
+	ContentType string
+	// FileName is an optional suggested name, sent in the Content-Disposition header.
+	FileName string
+	// Inline sends the content with Content-Disposition inline instead of attachment.
+	Inline bool
+}
+
+// SendFileResponse implements FileResponse.
+func (f BytesFile) SendFileResponse(c *fiber.Ctx) error {
+	start, length, ok := contentRange(c, int64(len(f.Data)))
+	if !ok {
+		return nil
+	}
+	setFileHeaders(c, f.mediaType(), f.FileName, f.Inline)
+	return c.Send(f.Data[start : start+length])
+}
+
+// mediaType implements documentedFile.
+func (f BytesFile) mediaType() string {
+	if f.ContentType == "" {
+		return fiber.MIMEOctetStream
+	}
+	return f.ContentType
+}
+
+// StreamFile is a FileResponse copying Reader to the client without buffering it, for content
+// from object storage, archives built on the fly and the like. Reader is closed once sent, or
+// when sending fails, when it implements io.Closer. When Reader is an io.ReadSeeker, such as an *os.File, Range requests
+// are answered with 206 Partial Content.
+//
+// Usage in handler:
+//
+//	func (h *Handler) Export(c *fiber.Ctx) (interface{}, error) {
+//	    obj, err := h.bucket.Open(c.UserContext(), "exports/latest.zip")
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return autofiber.StreamFile{Reader: obj, Size: obj.Size, ContentType: "application/zip", FileName: "export.zip"}, nil
+//	}
+type StreamFile struct {
+	// Reader supplies the file content.
+	Reader io.Reader
+	// Size is the content length, sent as Content-Length. When zero it is measured by seeking an
+	// io.Seeker; other readers are then sent with chunked transfer encoding.
+	Size int64
+	// ContentType is the media type of the content; application/octet-stream when empty.
+	ContentType string
+	// FileName is an optional suggested name, sent in the Content-Disposition header.
+	FileName string
+	// Inline sends the content with Content-Disposition inline instead of attachment.
+	Inline bool
+}
+
+// SendFileResponse implements FileResponse.
+func (f StreamFile) SendFileResponse(c *fiber.Ctx) error {
+	if f.Reader == nil {
+		return errors.New("autofiber: StreamFile has no Reader")
+	}
+	seeker, ok := f.Reader.(io.ReadSeeker)
+	if !ok {
+		size := f.Size
+		if size == 0 {
+			size = -1 // unknown: chunked
+		}
+		setFileHeaders(c, f.mediaType(), f.FileName, f.Inline)
+		c.Context().SetBodyStream(f.Reader, int(size))
+		return nil
+	}
+
+	// The content runs from the reader's current position.
+	offset, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		closeReader(f.Reader)
+		return err
+	}
+	size := f.Size
+	if size == 0 {
+		end, err := seeker.Seek(0, io.SeekEnd)
+		if err != nil {
+			closeReader(f.Reader)
+			return err
+		}
+		size = end - offset
+	}
+	start, length, ok := contentRange(c, size)
+	if !ok {
+		closeReader(f.Reader)
+		return nil
+	}
+	if _, err := seeker.Seek(offset+start, io.SeekStart); err != nil {
+		closeReader(f.Reader)
+		return err
+	}
+	setFileHeaders(c, f.mediaType(), f.FileName, f.Inline)
+	body := io.Reader(io.LimitReader(seeker, length))
+	if closer, ok := f.Reader.(io.Closer); ok {
+		body = readCloser{Reader: body, Closer: closer}
+	}
+	c.Context().SetBodyStream(body, int(length))
+	return nil
+}
+
+// mediaType implements documentedFile.
+func (f StreamFile) mediaType() string {
+	if f.ContentType == "" {
+		return fiber.MIMEOctetStream
+	}
+	return f.ContentType
+}
+
+// readCloser is a view of a stream that still closes the underlying stream.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// closeReader closes r when it implements io.Closer.
+func closeReader(r io.Reader) {
+	if closer, ok := r.(io.Closer); ok {
+		_ = closer.Close()
+	}
+}
+
+// setFileHeaders sets the Content-Type and Content-Disposition headers of a file response.
+func setFileHeaders(c *fiber.Ctx, contentType, fileName string, inline bool) {
+	c.Set(fiber.HeaderContentType, contentType)
+	disposition := "attachment"
+	if inline {
+		disposition = "inline"
+	}
+	if fileName != "" {
+		// FormatMediaType quotes the name and encodes non-ASCII names per RFC 2231.
+		if formatted := mime.FormatMediaType(disposition, map[string]string{"filename": fileName}); formatted != "" {
+			disposition = formatted
+		}
+	}
+	c.Set(fiber.HeaderContentDisposition, disposition)
+}
+
+// contentRange applies the request's Range header to content of size bytes and returns the part
+// to send. A satisfiable single byte range gets 206 Partial Content and its Content-Range; any
+// other request, including one with several ranges, gets the whole content. A range beyond the
+// content is answered with 416 Range Not Satisfiable, reported by ok false.
+func contentRange(c *fiber.Ctx, size int64) (start, length int64, ok bool) {
+	c.Set(fiber.HeaderAcceptRanges, "bytes")
+	if c.Get(fiber.HeaderRange) == "" || (c.Method() != fiber.MethodGet && c.Method() != fiber.MethodHead) {
+		return 0, size, true
+	}
+	ranges, err := c.Range(int(size))
+	if ranges.Type != "bytes" {
+		return 0, size, true
+	}
+	if errors.Is(err, fiber.ErrRangeUnsatisfiable) {
+		c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes */%d", size))
+		c.Status(fiber.StatusRequestedRangeNotSatisfiable)
+		return 0, 0, false
+	}
+	if err != nil || len(ranges.Ranges) != 1 {
+		return 0, size, true
+	}
+	start, end := int64(ranges.Ranges[0].Start), int64(ranges.Ranges[0].End)
+	c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes %d-%d/%d", start, end, size))
+	c.Status(fiber.StatusPartialContent)
+	return start, end - start + 1, true
+}
+
+// mediaType implements documentedFile, guessing the media type from the Path extension.
+func (d DownloadFile) mediaType() string {
+	if mediaType := utils.GetMIME(filepath.Ext(d.Path)); mediaType != "" {
+		return mediaType
+	}
+	return fiber.MIMEOctetStream
+}
+
+// documentedFile is implemented by the built-in file responses, which all support Range requests,
+// to document their media type.
+type documentedFile interface {
+	mediaType() string
+}
+
+// fileResponseType is the reflect type of the FileResponse interface.
+var fileResponseType = reflect.TypeOf((*FileResponse)(nil)).Elem()
+
+// routeFileResponse reports whether a route responds with a file, from its response schema or
+// the handler's declared result type, returning the documented media type and whether Range
+// requests are supported.
+func routeFileResponse(route RouteInfo) (mediaType string, ranges bool, ok bool) {
+	var result interface{}
+	if route.Options != nil && route.Options.ResponseSchema != nil {
+		result = route.Options.ResponseSchema
+	} else if t := reflect.TypeOf(route.Handler); t != nil && t.Kind() == reflect.Func && t.NumOut() == 2 && t.Out(0).Kind() != reflect.Interface {
+		result = reflect.New(derefType(t.Out(0))).Elem().Interface()
+	}
+	if !isFileResponse(result) {
+		return "", false, false
+	}
+	// A pointer schema, possibly nil, is documented from the value it points to.
+	if v := reflect.ValueOf(result); v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v = reflect.New(v.Type().Elem())
+		}
+		result = v.Elem().Interface()
+	}
+	if file, isDocumented := result.(documentedFile); isDocumented {
+		return file.mediaType(), true, true
+	}
+	return fiber.MIMEOctetStream, false, true
+}
+
+// isFileResponse reports whether v, or a pointer to it, implements FileResponse.
+func isFileResponse(v interface{}) bool {
+	t := reflect.TypeOf(v)
+	return t != nil && (t.Implements(fileResponseType) || reflect.PointerTo(t).Implements(fileResponseType))
+}
+
+// binaryContent documents a file body of the given media type.
+func binaryContent(mediaType string) map[string]OpenAPIMediaType {
+	return map[string]OpenAPIMediaType{mediaType: {Schema: &OpenAPISchema{Type: "string", Format: "binary"}}}
+}
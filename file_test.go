@@ -0,0 +1,198 @@
+package autofiber_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autofiber "github.com/vuongtlt13/auto-fiber"
+)
+
+// trackingReader records whether it was closed.
+type trackingReader struct {
+	io.ReadSeeker
+	closed bool
+}
+
+func (r *trackingReader) Close() error {
+	r.closed = true
+	return nil
+}
+
+// failingSeeker fails to seek once its budget of successful seeks is spent.
+type failingSeeker struct {
+	io.ReadSeeker
+	seeks int
+}
+
+func (s *failingSeeker) Seek(offset int64, whence int) (int64, error) {
+	if s.seeks == 0 {
+		return 0, errors.New("seek failed")
+	}
+	s.seeks--
+	return s.ReadSeeker.Seek(offset, whence)
+}
+
+const fileContent = "0123456789abcdef"
+
+func fileApp(stream *trackingReader) *autofiber.AutoFiber {
+	app := autofiber.New(fiber.Config{})
+	app.Get("/bytes", func(c *fiber.Ctx) (autofiber.BytesFile, error) {
+		return autofiber.BytesFile{Data: []byte(fileContent), ContentType: "application/pdf", FileName: "report 1.pdf"}, nil
+	})
+	app.Get("/inline", func(c *fiber.Ctx) (interface{}, error) {
+		return autofiber.BytesFile{Data: []byte(fileContent), Inline: true}, nil
+	}, autofiber.WithResponseSchema(autofiber.BytesFile{ContentType: "image/png"}))
+	app.Get("/stream", func(c *fiber.Ctx) (interface{}, error) {
+		return autofiber.StreamFile{Reader: stream, ContentType: "text/plain", FileName: "data.txt"}, nil
+	}, autofiber.WithResponseSchema(autofiber.StreamFile{ContentType: "text/plain"}))
+	app.Get("/pipe", func(c *fiber.Ctx) (interface{}, error) {
+		return autofiber.StreamFile{Reader: io.MultiReader(strings.NewReader(fileContent))}, nil
+	})
+	return app
+}
+
+func getRange(t *testing.T, app *autofiber.AutoFiber, path, rangeHeader string) (*http.Response, string) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if rangeHeader != "" {
+		req.Header.Set("Range", rangeHeader)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(body)
+}
+
+func TestBytesFile(t *testing.T) {
+	app := fileApp(nil)
+
+	resp, body := getRange(t, app, "/bytes", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, fileContent, body)
+	assert.Equal(t, "application/pdf", resp.Header.Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="report 1.pdf"`, resp.Header.Get("Content-Disposition"))
+	assert.Equal(t, "bytes", resp.Header.Get("Accept-Ranges"))
+	assert.Equal(t, int64(len(fileContent)), resp.ContentLength)
+
+	resp, _ = getRange(t, app, "/inline", "")
+	assert.Equal(t, fiber.MIMEOctetStream, resp.Header.Get("Content-Type"))
+	assert.Equal(t, "inline", resp.Header.Get("Content-Disposition"))
+}
+
+func TestFileResponses_Range(t *testing.T) {
+	cases := []struct {
+		name         string
+		rangeHeader  string
+		status       int
+		body         string
+		contentRange string
+	}{
+		{"first bytes", "bytes=0-3", http.StatusPartialContent, "0123", "bytes 0-3/16"},
+		{"open ended", "bytes=10-", http.StatusPartialContent, "abcdef", "bytes 10-15/16"},
+		{"suffix", "bytes=-4", http.StatusPartialContent, "cdef", "bytes 12-15/16"},
+		{"end clamped", "bytes=14-100", http.StatusPartialContent, "ef", "bytes 14-15/16"},
+		{"unsatisfiable", "bytes=20-30", http.StatusRequestedRangeNotSatisfiable, "", "bytes */16"},
+		{"multiple ranges send everything", "bytes=0-1,4-5", http.StatusOK, fileContent, ""},
+		{"malformed sends everything", "bytes0-1", http.StatusOK, fileContent, ""},
+		{"other units send everything", "items=0-1", http.StatusOK, fileContent, ""},
+	}
+	for _, path := range []string{"/bytes", "/stream"} {
+		for _, tc := range cases {
+			t.Run(path+" "+tc.name, func(t *testing.T) {
+				stream := &trackingReader{ReadSeeker: strings.NewReader(fileContent)}
+				resp, body := getRange(t, fileApp(stream), path, tc.rangeHeader)
+				assert.Equal(t, tc.status, resp.StatusCode)
+				assert.Equal(t, tc.body, body)
+				assert.Equal(t, tc.contentRange, resp.Header.Get("Content-Range"))
+				if path == "/stream" {
+					assert.True(t, stream.closed)
+				}
+			})
+		}
+	}
+}
+
+func TestStreamFile(t *testing.T) {
+	// A seekable reader starting mid-stream sends the rest, with its length.
+	reader := bytes.NewReader([]byte("skip" + fileContent))
+	_, err := reader.Seek(4, io.SeekStart)
+	require.NoError(t, err)
+	stream := &trackingReader{ReadSeeker: reader}
+	resp, body := getRange(t, fileApp(stream), "/stream", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, fileContent, body)
+	assert.Equal(t, int64(len(fileContent)), resp.ContentLength)
+	assert.Equal(t, `attachment; filename=data.txt`, resp.Header.Get("Content-Disposition"))
+
+	// Other readers are sent whole, without range support.
+	resp, body = getRange(t, fileApp(nil), "/pipe", "bytes=0-3")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, fileContent, body)
+	assert.Empty(t, resp.Header.Get("Accept-Ranges"))
+	assert.Equal(t, fiber.MIMEOctetStream, resp.Header.Get("Content-Type"))
+}
+
+func TestStreamFile_ClosedOnSeekError(t *testing.T) {
+	// Fail the position lookup, the size measurement and the seek to the range start in turn.
+	for seeks := 0; seeks < 3; seeks++ {
+		stream := &trackingReader{ReadSeeker: &failingSeeker{ReadSeeker: strings.NewReader(fileContent), seeks: seeks}}
+		resp, _ := getRange(t, fileApp(stream), "/stream", "bytes=2-5")
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode, seeks)
+		assert.True(t, stream.closed, seeks)
+	}
+}
+
+func TestFileResponses_DocsPointerResults(t *testing.T) {
+	app := autofiber.New(fiber.Config{})
+	app.Get("/download", func(c *fiber.Ctx) (*autofiber.DownloadFile, error) {
+		return &autofiber.DownloadFile{Path: "./report.pdf"}, nil
+	})
+	app.Get("/bytes", func(c *fiber.Ctx) (*autofiber.BytesFile, error) {
+		return &autofiber.BytesFile{Data: []byte(fileContent)}, nil
+	})
+	app.Get("/stream", func(c *fiber.Ctx) (interface{}, error) {
+		return nil, nil
+	}, autofiber.WithResponseSchema((*autofiber.StreamFile)(nil)))
+
+	var spec *autofiber.OpenAPISpec
+	require.NotPanics(t, func() { spec = app.GetOpenAPISpec() })
+	for _, path := range []string{"/download", "/bytes", "/stream"} {
+		responses := spec.Paths[path].Get.Responses
+		assert.Contains(t, responses["200"].Content, fiber.MIMEOctetStream, path)
+		assert.Contains(t, responses, "206", path)
+	}
+}
+
+func TestFileResponses_Docs(t *testing.T) {
+	spec := fileApp(nil).GetOpenAPISpec()
+
+	responses := spec.Paths["/bytes"].Get.Responses
+	require.Contains(t, responses, "200")
+	// The result type alone does not tell the content type.
+	content := responses["200"].Content
+	require.Contains(t, content, fiber.MIMEOctetStream)
+	assert.Equal(t, "string", content[fiber.MIMEOctetStream].Schema.Type)
+	assert.Equal(t, "binary", content[fiber.MIMEOctetStream].Schema.Format)
+	assert.Contains(t, responses["200"].Headers, "Content-Disposition")
+	require.Contains(t, responses, "206")
+	assert.Contains(t, responses["206"].Headers, "Content-Range")
+	assert.Contains(t, responses["206"].Content, fiber.MIMEOctetStream)
+	assert.Contains(t, responses, "416")
+
+	assert.Contains(t, spec.Paths["/inline"].Get.Responses["200"].Content, "image/png")
+	assert.Contains(t, spec.Paths["/stream"].Get.Responses["200"].Content, "text/plain")
+	assert.NotContains(t, spec.Components.Schemas, "BytesFile")
+	assert.NotContains(t, spec.Components.Schemas, "StreamFile")
+
+	// Routes returning interface{} without a file response schema are not documented as files.
+	assert.Nil(t, spec.Paths["/pipe"].Get.Responses["200"].Content)
+}
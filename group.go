@@ -7,11 +7,12 @@ import (
 
 // AutoFiberGroup represents a group of routes with a common prefix and shared middleware.
 type AutoFiberGroup struct {
-	Group              *fiber.Group    // Underlying Fiber group
-	app                *AutoFiber      // Reference to the parent AutoFiber app
-	Prefix             string          // Prefix of the group
-	groupMiddleware    []fiber.Handler // Middleware applied to every route in the group
-	groupRequireJWTAuth bool           // When true, every route in the group requires JWT auth
+	Group               *fiber.Group                  // Underlying Fiber group
+	app                 *AutoFiber                    // Reference to the parent AutoFiber app
+	Prefix              string                        // Prefix of the group
+	groupMiddleware     []fiber.Handler               // Middleware applied to every route in the group
+	groupRequireJWTAuth bool                          // When true, every route in the group requires JWT auth
+	groupErrorHandler   func(*fiber.Ctx, error) error // Error handler for routes without their own
 }
 
 // WithMiddleware adds middleware that will be prepended to every route registered in this group.
@@ -38,11 +39,28 @@ func (ag *AutoFiberGroup) WithJwtAuth() *AutoFiberGroup {
 	return ag
 }
 
+// WithErrorHandler sets the error handler for every route registered in this group. It receives
+// the routes' parse, validation and handler errors and takes precedence over the app-level
+// handling; a route's own WithRouteErrorHandler still wins. Returns the group for chaining.
+//
+// Example:
+//
+//	admin := app.Group("/admin").WithErrorHandler(func(c *fiber.Ctx, err error) error {
+//	    return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"admin_error": err.Error()})
+//	})
+func (ag *AutoFiberGroup) WithErrorHandler(fn func(*fiber.Ctx, error) error) *AutoFiberGroup {
+	ag.groupErrorHandler = fn
+	return ag
+}
+
 // mergeOpts copies group-level settings into the per-route opts.
 func (ag *AutoFiberGroup) mergeOpts(opts *RouteOptions) {
 	if ag.groupRequireJWTAuth {
 		opts.RequireJWTAuth = true
 	}
+	if opts.ErrorHandler == nil {
+		opts.ErrorHandler = ag.groupErrorHandler
+	}
 	if len(ag.groupMiddleware) > 0 {
 		// Group middleware runs before route-specific middleware.
 		opts.Middleware = append(ag.groupMiddleware, opts.Middleware...)
@@ -1,8 +1,11 @@
 package autofiber_test
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
@@ -274,3 +277,58 @@ func TestGroup_Docs_AddRoute(t *testing.T) {
 		assert.Equal(t, "Test group docs add route", spec.Paths["/api/docs-test"].Post.Description)
 	}
 }
+
+func TestGroup_WithErrorHandler(t *testing.T) {
+	af := autofiber.New(fiber.Config{}, autofiber.WithErrorHandler(func(c *fiber.Ctx, err error) error {
+		return c.Status(http.StatusTeapot).JSON(fiber.Map{"handler": "app"})
+	}))
+	admin := af.Group("/admin").WithErrorHandler(func(c *fiber.Ctx, err error) error {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"handler": "group", "error": err.Error()})
+	})
+
+	type Req struct {
+		Name string `json:"name" validate:"required"`
+	}
+	admin.Post("/users", func(c *fiber.Ctx, req *Req) (interface{}, error) {
+		return req, nil
+	}, autofiber.WithRequestSchema(Req{}))
+	admin.Get("/fail", func(c *fiber.Ctx) (interface{}, error) {
+		return nil, autofiber.NotFound("user not found")
+	})
+	admin.Get("/own", func(c *fiber.Ctx) (interface{}, error) {
+		return nil, errors.New("boom")
+	}, autofiber.WithRouteErrorHandler(func(c *fiber.Ctx, err error) error {
+		return c.Status(http.StatusConflict).JSON(fiber.Map{"handler": "route"})
+	}))
+	af.Post("/public", func(c *fiber.Ctx, req *Req) (interface{}, error) {
+		return req, nil
+	}, autofiber.WithRequestSchema(Req{}))
+
+	cases := []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		status  int
+		handler string
+	}{
+		{"validation error uses group handler", http.MethodPost, "/admin/users", `{}`, http.StatusBadRequest, "group"},
+		{"parse error uses group handler", http.MethodPost, "/admin/users", `{`, http.StatusBadRequest, "group"},
+		{"handler error uses group handler", http.MethodGet, "/admin/fail", "", http.StatusBadRequest, "group"},
+		{"route handler wins over group", http.MethodGet, "/admin/own", "", http.StatusConflict, "route"},
+		{"routes outside the group keep app handler", http.MethodPost, "/public", `{}`, http.StatusTeapot, "app"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := af.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.status, resp.StatusCode)
+
+			var payload map[string]interface{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&payload))
+			assert.Equal(t, tc.handler, payload["handler"])
+		})
+	}
+}
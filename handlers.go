@@ -2,7 +2,10 @@
 package autofiber
 
 import (
+	"context"
+	"errors"
 	"reflect"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -12,122 +15,419 @@ import (
 // Supported handler signatures:
 // 1. func(*fiber.Ctx) (interface{}, error) or (*ResponseSchema, error) -- for endpoints without request schema
 // 2. func(*fiber.Ctx, req *T) (interface{}, error) or (*ResponseSchema, error) -- for endpoints with request schema
+// Either form may take a context.Context instead of *fiber.Ctx (see requestContext).
 // When WithResponseSchema is provided, you can return the concrete schema type instead of interface{}.
-// All other signatures will panic.
+// All other signatures will panic. With WithPanicRecovery, panics while serving a request are recovered.
 func (af *AutoFiber) createHandlerWithOptions(handler interface{}, opts *RouteOptions) fiber.Handler {
+	return af.withRecovery(af.buildHandler(handler, opts), opts)
+}
+
+// withRecovery wraps h with panic recovery when WithPanicRecovery is enabled.
+func (af *AutoFiber) withRecovery(h fiber.Handler, opts *RouteOptions) fiber.Handler {
+	if af.recoverPanics {
+		return af.recoverHandler(h, opts)
+	}
+	return h
+}
+
+// invokeFunc calls a route handler with the parsed request, which is nil for routes without a
+// request schema, and returns the handler's result.
+type invokeFunc func(c *fiber.Ctx, req interface{}) (interface{}, error)
+
+// buildHandler checks the signature of handler and builds the adapter that parses, validates,
+// invokes handler through reflection and sends its response.
+func (af *AutoFiber) buildHandler(handler interface{}, opts *RouteOptions) fiber.Handler {
 	handlerType := reflect.TypeOf(handler)
 
 	if handlerType.Kind() != reflect.Func {
 		panic("Handler must be a function")
 	}
+	validOut := handlerType.NumOut() == 2 || isNoContentHandler(handler)
 
 	if opts.RequestSchema == nil {
-		// Allow func(*fiber.Ctx) (interface{}, error) or (*ResponseSchema, error)
-		if handlerType.NumIn() == 1 && handlerType.NumOut() == 2 {
-			return func(c *fiber.Ctx) error {
-				// Enforce Authorization header when JWT auth is required (no request schema to validate it)
-				if opts.RequireJWTAuth && c.Get("Authorization") == "" {
-					return fiber.NewError(fiber.StatusUnauthorized, "Missing Authorization header")
-				}
+		// Allow func(*fiber.Ctx) (interface{}, error), (*ResponseSchema, error) or error
+		if handlerType.NumIn() == 1 && validOut {
+			return af.adapt(handler, opts, reflectInvoke(handler, opts))
+		}
+		panic("Handler must be func(*fiber.Ctx) (interface{}, error), (*ResponseSchema, error) or error when no request schema is provided")
+	}
 
-				results := reflect.ValueOf(handler).Call([]reflect.Value{reflect.ValueOf(c)})
-				data := results[0].Interface()
-				err, _ := results[1].Interface().(error)
-				if err != nil {
-					return err
-				}
+	// With request schema: allow func(*fiber.Ctx, req *T) (interface{}, error), (*ResponseSchema, error) or error
+	if handlerType.NumIn() == 2 && validOut {
+		return af.adapt(handler, opts, reflectInvoke(handler, opts))
+	}
 
-				// If handler returned a FileResponse, send file directly (no JSON / validation).
-				if fr, ok := data.(FileResponse); ok {
-					return fr.SendFileResponse(c)
-				}
+	panic("Handler must be func(*fiber.Ctx) (interface{}, error), (*ResponseSchema, error) or error, or func(*fiber.Ctx, req *T) (interface{}, error), (*ResponseSchema, error) or error")
+}
 
-				// Validate response if schema is set
-				if opts.ResponseSchema != nil {
-					c.Locals("response_schema", opts.ResponseSchema)
-					c.Locals("response_validator", af.validator)
-					return ValidateAndJSON(c, data)
-				}
-				return c.JSON(data)
+// errorType is the reflect type of the error interface.
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// isNoContentHandler reports whether handler returns only an error, such as func(*fiber.Ctx, *T) error.
+// Such routes respond with 204 No Content on success.
+func isNoContentHandler(handler interface{}) bool {
+	t := reflect.TypeOf(handler)
+	return t != nil && t.Kind() == reflect.Func && t.NumOut() == 1 && t.Out(0) == errorType
+}
+
+// noContent is the result of an error-only handler that succeeded.
+type noContent struct{}
+
+// reflectInvoke calls handler through reflection, passing c, or a context derived from it when
+// the handler's first parameter is a context.Context, followed by the parsed request if any.
+//
+// When the result is streamed, the handler's context outlives the call and is cancelled when the
+// stream ends. Handlers taking *fiber.Ctx whose declared result is a stream get the same through
+// c.UserContext().
+func reflectInvoke(handler interface{}, opts *RouteOptions) invokeFunc {
+	fn := reflect.ValueOf(handler)
+	takesContext := fn.Type().In(0) == contextType
+	_, declaresStream := handlerStreamItemType(handler)
+	errorOnly := isNoContentHandler(handler)
+	return func(c *fiber.Ctx, req interface{}) (interface{}, error) {
+		args := make([]reflect.Value, 0, 2)
+		cancel := func() {}
+		var ctx localsContext
+		switch {
+		case takesContext:
+			ctx, cancel = requestContext(c, opts)
+			args = append(args, reflect.ValueOf(ctx))
+		case declaresStream:
+			var userCtx context.Context
+			userCtx, cancel = context.WithCancel(c.UserContext())
+			c.SetUserContext(userCtx)
+			args = append(args, reflect.ValueOf(c))
+		default:
+			args = append(args, reflect.ValueOf(c))
+		}
+		streaming := false
+		defer func() {
+			if !streaming {
+				cancel()
 			}
+		}()
+		if req != nil {
+			args = append(args, reflect.ValueOf(req))
 		}
-		panic("Handler must be func(*fiber.Ctx) (interface{}, error) or (*ResponseSchema, error) when no request schema is provided")
+		results := fn.Call(args)
+		if errorOnly {
+			err, _ := results[0].Interface().(error)
+			return noContent{}, err
+		}
+		err, _ := results[1].Interface().(error)
+		data := results[0].Interface()
+		if _, ok := streamItems(data); ok && err == nil {
+			streaming = true
+			if takesContext {
+				ctx.detach()
+			}
+			return streamResult{data: data, done: cancel}, nil
+		}
+		return data, err
 	}
+}
 
-	// With request schema: allow func(*fiber.Ctx, req *T) (interface{}, error) or (*ResponseSchema, error)
-	if handlerType.NumIn() == 2 && handlerType.NumOut() == 2 {
-		// Build the parse middleware once at registration time (not per request).
-		parseMiddleware := AutoParseRequest(opts.RequestSchema, af.validator)
+// streamResult is a channel or iterator result with the func cancelling the handler's context,
+// called once the stream ends.
+type streamResult struct {
+	data interface{}
+	done func()
+}
+
+// adapt builds the fiber handler that parses and validates the request described by opts,
+// calls invoke and sends its result. Requests whose Accept header matches none of the media
+// types the route can produce get 406 Not Acceptable before anything else runs.
+func (af *AutoFiber) adapt(handler interface{}, opts *RouteOptions, invoke invokeFunc) fiber.Handler {
+	offers, checkAccept := af.encoders.routeOffers(handler, opts)
+	acceptable := func(c *fiber.Ctx) bool {
+		return !checkAccept || (len(offers) > 0 && c.Accepts(offers...) != "")
+	}
+
+	if opts.RequestSchema == nil {
 		return func(c *fiber.Ctx) error {
-			if err := parseMiddleware(c); err != nil {
-				// Handle parse errors
-				if parseErr, ok := err.(*ParseError); ok {
-					return af.handleError(c, &ValidationRequestError{
-						Message: "Invalid request",
-						Details: []FieldErrorDetail{{
-							Field:   parseErr.Field,
-							Message: parseErr.Message,
-							Tag:     "parse",
-						}},
-					})
-				}
+			if !acceptable(c) {
+				return af.handleRouteError(c, opts, notAcceptable(c))
+			}
 
-				// If JWT auth is required and Authorization header is missing -> 401
-				if opts.RequireJWTAuth && c.Get("Authorization") == "" {
-					return fiber.NewError(fiber.StatusUnauthorized, "Missing Authorization header")
-				}
+			// Enforce Authorization header when JWT auth is required (no request schema to validate it)
+			if opts.RequireJWTAuth && c.Get("Authorization") == "" {
+				return af.handleRouteError(c, opts, fiber.NewError(fiber.StatusUnauthorized, "Missing Authorization header"))
+			}
 
-				// Handle validation errors (from validator)
-				if validationErrs, ok := err.(validator.ValidationErrors); ok {
-					var details []FieldErrorDetail
-					for _, verr := range validationErrs {
-						details = append(details, FieldErrorDetail{
-							Field:   verr.Namespace(),
-							Message: verr.Error(),
-							Tag:     verr.Tag(),
-						})
-					}
-					return af.handleError(c, &ValidationRequestError{
-						Message: "Validation failed",
-						Details: details,
-					})
-				}
-				return af.handleError(c, &ValidationRequestError{
-					Message: err.Error(),
-				})
+			if err := selectFields(c, opts, nil); err != nil {
+				return af.handleRouteError(c, opts, err)
 			}
-			req := c.Locals("parsed_request")
-			if req == nil {
-				return af.handleError(c, &ValidationRequestError{Message: "Invalid request"})
+
+			data, err := invoke(c, nil)
+			return af.finishResponse(c, opts, data, err)
+		}
+	}
+
+	// Build the parse middleware once at registration time (not per request).
+	parseMiddleware := AutoParseRequest(opts.RequestSchema, af.validator)
+	schemaType := derefType(reflect.TypeOf(opts.RequestSchema))
+	return func(c *fiber.Ctx) error {
+		if !acceptable(c) {
+			return af.handleRouteError(c, opts, notAcceptable(c))
+		}
+
+		if err := parseMiddleware(c); err != nil {
+			// Handle parse errors
+			var parseErr *ParseError
+			if errors.As(err, &parseErr) {
+				return af.handleRouteError(c, opts, &ValidationRequestError{
+					Message: "Invalid request",
+					Code:    CodeInvalidRequest,
+					Details: []FieldErrorDetail{parseErrorDetail(parseErr)},
+					Status:  fiber.StatusBadRequest,
+				})
 			}
 
-			// Enforce Authorization header when JWT auth is required.
-			// Even though RequestSchema could already require it, this guarantees presence.
+			// If JWT auth is required and Authorization header is missing -> 401
 			if opts.RequireJWTAuth && c.Get("Authorization") == "" {
-				return fiber.NewError(fiber.StatusUnauthorized, "Missing Authorization header")
+				return af.handleRouteError(c, opts, fiber.NewError(fiber.StatusUnauthorized, "Missing Authorization header"))
 			}
 
-			results := reflect.ValueOf(handler).Call([]reflect.Value{reflect.ValueOf(c), reflect.ValueOf(req)})
-			data := results[0].Interface()
-			err, _ := results[1].Interface().(error)
-			if err != nil {
-				return err
+			// Handle validation errors (from validator and the schema's Validate hook)
+			var hookErr *requestValidationError
+			if errors.As(err, &hookErr) {
+				return af.handleRouteError(c, opts, &ValidationRequestError{
+					Message: "Validation failed",
+					Code:    CodeValidationFailed,
+					Details: append(af.validationErrorDetails(c, schemaType, hookErr.tags), hookErrorDetails(hookErr.hook)...),
+					Status:  fiber.StatusUnprocessableEntity,
+				})
 			}
-
-			// If handler returned a FileResponse, send file directly (no JSON / validation).
-			if fr, ok := data.(FileResponse); ok {
-				return fr.SendFileResponse(c)
+			if validationErrs, ok := err.(validator.ValidationErrors); ok {
+				return af.handleRouteError(c, opts, &ValidationRequestError{
+					Message: "Validation failed",
+					Code:    CodeValidationFailed,
+					Details: af.validationErrorDetails(c, schemaType, validationErrs),
+					Status:  fiber.StatusUnprocessableEntity,
+				})
 			}
+			return af.handleRouteError(c, opts, &ValidationRequestError{
+				Message: err.Error(),
+				Code:    CodeInvalidRequest,
+				Status:  fiber.StatusBadRequest,
+			})
+		}
+		req := c.Locals("parsed_request")
+		if req == nil {
+			return af.handleRouteError(c, opts, &ValidationRequestError{Message: "Invalid request", Code: CodeInvalidRequest, Status: fiber.StatusBadRequest})
+		}
 
-			// Validate response if schema is set
-			if opts.ResponseSchema != nil {
-				c.Locals("response_schema", opts.ResponseSchema)
-				c.Locals("response_validator", af.validator)
-				return ValidateAndJSON(c, data)
+		// Run context-aware validators once synchronous validation has passed.
+		asyncDetails, asyncErr := af.runAsyncValidators(c, schemaType, req)
+		if asyncErr != nil {
+			return af.handleRouteError(c, opts, asyncErr)
+		}
+		if len(asyncDetails) > 0 {
+			return af.handleRouteError(c, opts, &ValidationRequestError{
+				Message: "Validation failed",
+				Code:    CodeValidationFailed,
+				Details: asyncDetails,
+				Status:  fiber.StatusUnprocessableEntity,
+			})
+		}
+
+		// Enforce Authorization header when JWT auth is required.
+		// Even though RequestSchema could already require it, this guarantees presence.
+		if opts.RequireJWTAuth && c.Get("Authorization") == "" {
+			return af.handleRouteError(c, opts, fiber.NewError(fiber.StatusUnauthorized, "Missing Authorization header"))
+		}
+
+		if err := selectFields(c, opts, req); err != nil {
+			return af.handleRouteError(c, opts, err)
+		}
+
+		data, err := invoke(c, req)
+		return af.finishResponse(c, opts, data, err)
+	}
+}
+
+// finishResponse handles the result of a route handler: errors go to handlerError, an error-only
+// handler's success is sent without a body, a FileResponse is sent directly (no JSON / validation),
+// a channel or iterator is streamed and anything else goes through sendResponse.
+func (af *AutoFiber) finishResponse(c *fiber.Ctx, opts *RouteOptions, data interface{}, err error) error {
+	if err != nil {
+		return af.handlerError(c, opts, err)
+	}
+	if _, ok := data.(noContent); ok {
+		c.Status(opts.noContentStatus())
+		return nil
+	}
+	if fr, ok := data.(FileResponse); ok {
+		return fr.SendFileResponse(c)
+	}
+	if s, ok := data.(streamResult); ok {
+		return af.sendStream(c, opts, s.data, s.done)
+	}
+	if _, ok := streamItems(data); ok {
+		return af.sendStream(c, opts, data, nil)
+	}
+	return af.sendResponse(c, opts, data)
+}
+
+// handlerError passes an error returned by a handler to the route's error handler when one is set.
+// Otherwise it maps a typed *HTTPError to its status and body, and returns other errors unchanged
+// for fiber's error handler.
+func (af *AutoFiber) handlerError(c *fiber.Ctx, opts *RouteOptions, err error) error {
+	if opts.ErrorHandler != nil {
+		return opts.ErrorHandler(c, err)
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return af.handleError(c, httpErr)
+	}
+	return err
+}
+
+// handleRouteError passes an error generated for a route to the route's error handler when one
+// is set (from WithRouteErrorHandler or the group's WithErrorHandler), or to handleError otherwise.
+func (af *AutoFiber) handleRouteError(c *fiber.Ctx, opts *RouteOptions, err error) error {
+	if opts.ErrorHandler != nil {
+		return opts.ErrorHandler(c, err)
+	}
+	return af.handleError(c, err)
+}
+
+// sendResponse validates data against the route's response schema, according to the effective
+// response validation mode, and writes it with the route's success status, encoded in the media
+// type negotiated from the Accept header. A Response result supplies its own status and headers,
+// and its Body is what gets validated and written.
+func (af *AutoFiber) sendResponse(c *fiber.Ctx, opts *RouteOptions, result interface{}) error {
+	parts := unwrapResponse(result)
+	data, status := parts.body, parts.status
+	if status == 0 {
+		status = opts.successStatus()
+	}
+	encoder, ok := af.encoders.negotiate(c, opts, data)
+	if !ok {
+		return af.handleRouteError(c, opts, notAcceptable(c))
+	}
+	write := func() error {
+		payload := data
+		if encoder.MediaType() == fiber.MIMEApplicationJSON {
+			if fields, _ := c.Locals(fieldsParam).([]string); len(fields) > 0 {
+				projected, err := af.projectJSON(data, fields)
+				if err != nil {
+					return err
+				}
+				payload = projected
+			}
+			if af.envelope != nil {
+				payload = af.envelope.wrap(c, payload, parts.meta)
 			}
-			return c.JSON(data)
 		}
+		body, err := encoder.Encode(payload)
+		if err != nil {
+			return err
+		}
+		for name, value := range parts.headers {
+			c.Set(name, value)
+		}
+		c.Set(fiber.HeaderContentType, encoder.MediaType())
+		return c.Status(status).Send(body)
+	}
+
+	schema := opts.responseSchemaFor(status)
+	if schema == nil {
+		return write()
+	}
+
+	mode := af.responseValidationMode(opts)
+	if !mode.sampled() {
+		return write()
+	}
+
+	c.Locals("response_schema", schema)
+	c.Locals("response_validator", af.validator)
+	if verr := validateResponse(data, schema, af.validator); verr != nil {
+		if mode.enforced() {
+			return af.handleRouteError(c, opts, verr)
+		}
+		af.reportResponseError(c, verr)
+	}
+	return write()
+}
+
+// responseValidationMode returns the route's response validation mode, falling back to the app's.
+func (af *AutoFiber) responseValidationMode(opts *RouteOptions) ResponseValidationMode {
+	if opts.ResponseValidation != nil {
+		return *opts.ResponseValidation
 	}
+	return af.responseValidation
+}
 
-	panic("Handler must be func(*fiber.Ctx) (interface{}, error) or (*ResponseSchema, error), or func(*fiber.Ctx, req *T) (interface{}, error) or (*ResponseSchema, error)")
+// reportResponseError passes a response validation failure to the configured reporter,
+// or logs it when none is set.
+func (af *AutoFiber) reportResponseError(c *fiber.Ctx, err *ValidationResponseError) {
+	if af.responseReporter != nil {
+		af.responseReporter(c, err)
+		return
+	}
+	af.logger.Warnf("autofiber: %s %s: %s: %s", c.Method(), c.Path(), err.Message, responseValidationErrors(err.Details).Error())
+}
+
+// validationErrorDetails converts validator errors on a request of type root into field details
+// addressed by wire names, with messages localized for the request's Accept-Language header.
+func (af *AutoFiber) validationErrorDetails(c *fiber.Ctx, root reflect.Type, errs validator.ValidationErrors) []FieldErrorDetail {
+	trans := af.translations.forRequest(c)
+	details := make([]FieldErrorDetail, 0, len(errs))
+	for _, verr := range errs {
+		details = append(details, requestFieldDetail(c, root, verr.StructNamespace(), af.translations.message(trans, verr), verr.Tag()))
+	}
+	return details
+}
+
+// requestFieldDetail builds the detail for a failing field of a request of type root, identified by
+// its struct namespace, addressing it by wire name with its source and, for body fields, a JSON pointer.
+func requestFieldDetail(c *fiber.Ctx, root reflect.Type, structNamespace, message, tag string) FieldErrorDetail {
+	path, source := requestFieldPath(c, root, structNamespace)
+	detail := FieldErrorDetail{
+		Field:   formatFieldPath(path),
+		Message: message,
+		Tag:     tag,
+		Source:  string(source),
+	}
+	if source == Body {
+		detail.Pointer = jsonPointer(path)
+	}
+	return detail
+}
+
+// hookErrorDetails converts the error returned by a RequestValidator hook into field details.
+// FieldErrors and ValidationRequestError details are used as-is; any other error becomes a
+// single detail carrying its message.
+func hookErrorDetails(err error) []FieldErrorDetail {
+	var fieldErrs FieldErrors
+	if errors.As(err, &fieldErrs) {
+		return fieldErrs
+	}
+	var reqErr *ValidationRequestError
+	if errors.As(err, &reqErr) && len(reqErr.Details) > 0 {
+		return reqErr.Details
+	}
+	return []FieldErrorDetail{{Message: err.Error(), Tag: "validate"}}
+}
+
+// parseErrorDetail converts a ParseError into a field detail. Body field errors carry a JSON
+// pointer; errors about the body as a whole do not.
+func parseErrorDetail(parseErr *ParseError) FieldErrorDetail {
+	detail := FieldErrorDetail{
+		Field:   parseErr.Field,
+		Message: parseErr.Message,
+		Tag:     "parse",
+		Source:  parseErr.Source,
+		Code:    parseErr.Code,
+	}
+	if parseErr.Source == string(Body) && parseErr.Field != "body" {
+		var path []pathSegment
+		for _, key := range strings.Split(parseErr.Field, ".") {
+			path = append(path, pathSegment{key: key})
+		}
+		detail.Pointer = jsonPointer(path)
+	}
+	return detail
 }
@@ -0,0 +1,69 @@
+// Package autofiber provides typed HTTP errors that handlers can return to control the status and body.
+package autofiber
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// HTTPError is an error with an HTTP status, an optional machine-readable code and field details.
+// When a handler returns one (directly or wrapped), AutoFiber responds with its status and a JSON
+// body, or problem details when WithProblemDetails is enabled.
+//
+// Example:
+//
+//	func getUser(c *fiber.Ctx, req *GetUserRequest) (*User, error) {
+//	    user, ok := users[req.ID]
+//	    if !ok {
+//	        return nil, autofiber.NotFound("user not found").WithCode("user_not_found")
+//	    }
+//	    return user, nil
+//	}
+type HTTPError struct {
+	Status    int                `json:"-"`
+	Message   string             `json:"error"`
+	Code      string             `json:"code,omitempty"`
+	Details   []FieldErrorDetail `json:"details,omitempty"`
+	RequestID string             `json:"request_id,omitempty"` // set on errors from recovered panics
+}
+
+// NewHTTPError creates an HTTPError with the given status and message.
+func NewHTTPError(status int, message string) *HTTPError {
+	return &HTTPError{Status: status, Message: message}
+}
+
+// NotFound creates a 404 Not Found error.
+func NotFound(message string) *HTTPError {
+	return NewHTTPError(fiber.StatusNotFound, message)
+}
+
+// Conflict creates a 409 Conflict error.
+func Conflict(message string) *HTTPError {
+	return NewHTTPError(fiber.StatusConflict, message)
+}
+
+// Forbidden creates a 403 Forbidden error.
+func Forbidden(message string) *HTTPError {
+	return NewHTTPError(fiber.StatusForbidden, message)
+}
+
+// Unprocessable creates a 422 Unprocessable Entity error with optional field details.
+func Unprocessable(message string, details ...FieldErrorDetail) *HTTPError {
+	return NewHTTPError(fiber.StatusUnprocessableEntity, message).WithDetails(details...)
+}
+
+// WithCode sets the machine-readable error code and returns e.
+func (e *HTTPError) WithCode(code string) *HTTPError {
+	e.Code = code
+	return e
+}
+
+// WithDetails appends field details and returns e.
+func (e *HTTPError) WithDetails(details ...FieldErrorDetail) *HTTPError {
+	e.Details = append(e.Details, details...)
+	return e
+}
+
+// Error implements the error interface for HTTPError
+func (e *HTTPError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,87 @@
+package autofiber_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	autofiber "github.com/vuongtlt13/auto-fiber"
+)
+
+func TestHTTPError_Constructors(t *testing.T) {
+	assert.Equal(t, http.StatusNotFound, autofiber.NotFound("x").Status)
+	assert.Equal(t, http.StatusConflict, autofiber.Conflict("x").Status)
+	assert.Equal(t, http.StatusForbidden, autofiber.Forbidden("x").Status)
+
+	err := autofiber.Unprocessable("invalid order", autofiber.FieldErrorDetail{Field: "qty", Message: "too many"}).WithCode("order_invalid")
+	assert.Equal(t, http.StatusUnprocessableEntity, err.Status)
+	assert.Equal(t, "order_invalid", err.Code)
+	assert.Len(t, err.Details, 1)
+	assert.EqualError(t, err, "invalid order")
+}
+
+func TestHTTPError_HandlerResponses(t *testing.T) {
+	app := newTestApp()
+	app.Get("/missing", func(c *fiber.Ctx) (interface{}, error) {
+		return nil, autofiber.NotFound("user not found").WithCode("user_not_found")
+	})
+	app.Get("/wrapped", func(c *fiber.Ctx) (interface{}, error) {
+		return nil, fmt.Errorf("creating user: %w", autofiber.Conflict("email already registered"))
+	})
+	app.Get("/plain", func(c *fiber.Ctx) (interface{}, error) {
+		return nil, errors.New("boom")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/missing", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	var body map[string]interface{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, map[string]interface{}{"error": "user not found", "code": "user_not_found"}, body)
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/wrapped", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/plain", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+}
+
+func TestHTTPError_ProblemDetails(t *testing.T) {
+	app := autofiber.New(fiber.Config{}, autofiber.WithProblemDetails())
+	app.Get("/forbidden", func(c *fiber.Ctx) (interface{}, error) {
+		return nil, autofiber.Forbidden("not your org").WithCode("org_forbidden")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/forbidden", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	var problem autofiber.ProblemDetails
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+	assert.Equal(t, "Forbidden", problem.Title)
+	assert.Equal(t, "not your org", problem.Detail)
+	assert.Equal(t, "org_forbidden", problem.Code)
+}
+
+func TestWithErrors_Docs(t *testing.T) {
+	type Req struct {
+		ID int `parse:"path:id"`
+	}
+	app := autofiber.New(fiber.Config{})
+	app.Get("/users/:id", func(c *fiber.Ctx, req *Req) (interface{}, error) {
+		return req, nil
+	}, autofiber.WithRequestSchema(Req{}), autofiber.WithErrors(http.StatusNotFound, http.StatusConflict))
+
+	responses := app.GetOpenAPISpec().Paths["/users/{id}"].Get.Responses
+	assert.Len(t, responses, 3)
+	assert.Contains(t, responses, "200")
+	assert.Equal(t, "Not Found", responses["404"].Description)
+	assert.Equal(t, "Conflict", responses["409"].Description)
+	assert.Contains(t, responses["404"].Content["application/json"].Schema.Properties, "code")
+}
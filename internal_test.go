@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -205,3 +207,53 @@ func TestConvertDefaultValue(t *testing.T) {
 	result = convertDefaultValue("invalid", reflect.TypeOf(0.0))
 	assert.Equal(t, "invalid", result)
 }
+
+func TestWithDefaultValidator(t *testing.T) {
+	original := validate
+	validate = validator.New()
+	t.Cleanup(func() { validate = original })
+	shared := validate
+
+	// The setup is replayed on the package validator whatever the option order.
+	af := New(fiber.Config{},
+		WithValidatorSetup(func(v *validator.Validate) {
+			_ = v.RegisterValidation("is_even", func(fl validator.FieldLevel) bool {
+				return fl.Field().Int()%2 == 0
+			})
+		}),
+		WithDefaultValidator(),
+	)
+	assert.NoError(t, af.RegisterValidator("is_odd", func(fl validator.FieldLevel) bool {
+		return fl.Field().Int()%2 == 1
+	}))
+	assert.Same(t, shared, GetValidator(), "the package default is not replaced")
+	assert.NotSame(t, shared, af.validator)
+
+	type Count struct {
+		Even int `json:"even" validate:"is_even"`
+		Odd  int `json:"odd" validate:"is_odd"`
+	}
+	assert.NoError(t, ValidateStruct(&Count{Even: 2, Odd: 1}))
+	assert.Error(t, ValidateStruct(&Count{Even: 3, Odd: 1}))
+	assert.Error(t, ValidateStruct(&Count{Even: 2, Odd: 2}))
+
+	// The package validator keeps Go field names; the app's messages use wire names.
+	var verrs validator.ValidationErrors
+	if assert.ErrorAs(t, ValidateStruct(&Count{Even: 3, Odd: 1}), &verrs) {
+		assert.Equal(t, "Even", verrs[0].Field())
+	}
+	var reqErr *ValidationRequestError
+	if assert.ErrorAs(t, af.ValidateStruct(&Count{Even: 3, Odd: 1}), &reqErr) {
+		assert.Equal(t, "even failed the 'is_even' validation", reqErr.Details[0].Message)
+	}
+
+	// Apps without the option leave the package validator untouched.
+	own := New(fiber.Config{})
+	assert.NoError(t, own.RegisterValidator("is_positive", func(fl validator.FieldLevel) bool {
+		return fl.Field().Int() > 0
+	}))
+	type Positive struct {
+		N int `validate:"is_positive"`
+	}
+	assert.Panics(t, func() { _ = ValidateStruct(&Positive{N: 1}) })
+}
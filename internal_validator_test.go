@@ -52,7 +52,7 @@ func TestValidateResponseData_WithSlices(t *testing.T) {
 	}
 	err = validateResponseData(data, Item{}, validator)
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "element 1")
+	assert.Contains(t, err.Error(), "response[1].Name")
 }
 
 func TestValidateResponseData_WithUnsupportedMapType(t *testing.T) {
@@ -164,3 +164,95 @@ func TestValidateResponseData_WithComplexMapTypes(t *testing.T) {
 	err = validateResponseData(fiberMap, ValidStruct{}, validator)
 	assert.NoError(t, err)
 }
+
+func TestValidateResponseData_ReportsEveryFieldWithPath(t *testing.T) {
+	type Item struct {
+		ID    int    `json:"id" validate:"required"`
+		Email string `json:"email" validate:"required,email"`
+	}
+	type Page struct {
+		Items []*Item `json:"items"`
+		Total int     `json:"total" validate:"gte=0"`
+	}
+	type APIResponse[T any] struct {
+		Code int `json:"code" validate:"required"`
+		Data T   `json:"data"`
+	}
+
+	data := APIResponse[Page]{
+		Data: Page{
+			Items: []*Item{{ID: 1, Email: "a@b.c"}, {Email: "bad"}},
+			Total: -1,
+		},
+	}
+	err := validateResponseData(data, APIResponse[Page]{}, GetValidator())
+	assert.Error(t, err)
+
+	details := responseErrorDetails(err)
+	var fields []string
+	for _, d := range details {
+		fields = append(fields, d.Field)
+	}
+	assert.ElementsMatch(t, []string{"response.code", "response.data.total", "response.data.items[1].id", "response.data.items[1].email"}, fields)
+}
+
+func TestValidateResponseData_MapsOfStructs(t *testing.T) {
+	type Item struct {
+		Name string `json:"name" validate:"required"`
+	}
+	type Resp struct {
+		ByKey map[string]Item `json:"byKey"`
+	}
+
+	err := validateResponseData(map[string]*Item{"a": {Name: "x"}, "b": {}}, Item{}, GetValidator())
+	assert.Error(t, err)
+	assert.Equal(t, "response.b.name", responseErrorDetails(err)[0].Field)
+
+	err = validateResponseData(Resp{ByKey: map[string]Item{"k": {}}}, Resp{}, GetValidator())
+	assert.Error(t, err)
+	assert.Equal(t, "response.byKey.k.name", responseErrorDetails(err)[0].Field)
+}
+
+func TestValidateResponseData_NestedMapsInSlices(t *testing.T) {
+	type Line struct {
+		SKU string `json:"sku" validate:"required"`
+	}
+	type Order struct {
+		ID    int    `json:"id" validate:"required"`
+		Lines []Line `json:"lines"`
+	}
+
+	data := []fiber.Map{
+		{"id": 1, "lines": []fiber.Map{{"sku": "A"}}},
+		{"id": 2, "lines": []fiber.Map{{"sku": "B"}, {"sku": ""}}},
+	}
+	err := validateResponseData(data, Order{}, GetValidator())
+	assert.Error(t, err)
+	details := responseErrorDetails(err)
+	assert.Len(t, details, 1)
+	assert.Equal(t, "response[1].lines[1].sku", details[0].Field)
+	assert.Equal(t, "required", details[0].Tag)
+}
+
+func TestValidateResponseData_Primitives(t *testing.T) {
+	validator := GetValidator()
+
+	assert.NoError(t, validateResponseData("ok", "", validator))
+	assert.NoError(t, validateResponseData([]int{1, 2}, 0, validator))
+	assert.NoError(t, validateResponseData([]string{"a"}, []string{}, validator))
+
+	err := validateResponseData([]interface{}{1, "two"}, 0, validator)
+	assert.Error(t, err)
+	details := responseErrorDetails(err)
+	assert.Len(t, details, 1)
+	assert.Equal(t, "response[1]", details[0].Field)
+	assert.Equal(t, "type", details[0].Tag)
+	assert.Equal(t, "expected integer, got string", details[0].Message)
+
+	type S struct {
+		ID int `json:"id"`
+	}
+	err = validateResponseData("oops", S{}, validator)
+	assert.Error(t, err)
+	assert.Equal(t, "response", responseErrorDetails(err)[0].Field)
+}
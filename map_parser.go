@@ -2,29 +2,109 @@
 package autofiber
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
+	"time"
+	"unicode"
 )
 
+// KeyNaming is a naming convention used to translate struct keys before they are looked up in a map.
+type KeyNaming string
+
+const (
+	// SnakeCase looks up keys as snake_case (e.g. "firstName" → "first_name").
+	SnakeCase KeyNaming = "snake_case"
+	// CamelCase looks up keys as camelCase (e.g. "first_name" → "firstName").
+	CamelCase KeyNaming = "camelCase"
+	// PascalCase looks up keys as PascalCase (e.g. "first_name" → "FirstName").
+	PascalCase KeyNaming = "PascalCase"
+	// KebabCase looks up keys as kebab-case (e.g. "firstName" → "first-name").
+	KebabCase KeyNaming = "kebab-case"
+)
+
+// MapParseOption configures how ParseFromMap and ParseFromInterface match map keys to struct fields.
+type MapParseOption func(*mapParseConfig)
+
+// mapParseConfig holds the key matching strategy for a single parse call.
+type mapParseConfig struct {
+	caseInsensitive bool
+	naming          KeyNaming
+	disallowUnknown bool
+}
+
+// MatchCaseInsensitive matches map keys to field keys ignoring case (e.g. "EMAIL" fills `json:"email"`).
+func MatchCaseInsensitive() MapParseOption {
+	return func(cfg *mapParseConfig) {
+		cfg.caseInsensitive = true
+	}
+}
+
+// MatchNaming also looks up each field key converted to the given naming convention,
+// so camelCase json tags can be filled from snake_case data and vice versa.
+// The exact key is always tried first.
+func MatchNaming(naming KeyNaming) MapParseOption {
+	return func(cfg *mapParseConfig) {
+		cfg.naming = naming
+	}
+}
+
+// DisallowUnknownKeys makes parsing fail when the map contains keys that were not mapped to any field.
+func DisallowUnknownKeys() MapParseOption {
+	return func(cfg *mapParseConfig) {
+		cfg.disallowUnknown = true
+	}
+}
+
 // ParseFromMap parses a struct from a map[string]interface{}.
 // It uses JSON tags to map keys to struct fields and sets the values accordingly.
 // The schema parameter must be a pointer to the target struct.
-func ParseFromMap(data map[string]interface{}, schema interface{}) error {
-	return parseFromMapInternal(data, schema)
+// Options control key matching (MatchCaseInsensitive, MatchNaming) and unknown keys (DisallowUnknownKeys).
+//
+// Example:
+//
+//	err := autofiber.ParseFromMap(msg, &event,
+//	    autofiber.MatchNaming(autofiber.SnakeCase),
+//	    autofiber.DisallowUnknownKeys(),
+//	)
+func ParseFromMap(data map[string]interface{}, schema interface{}, options ...MapParseOption) error {
+	return parseFromMapInternal(data, schema, options...)
 }
 
 // ParseFromInterface parses a struct from any interface{} (map, struct, etc.).
 // It supports map[string]interface{}, map[string]string, and struct types.
 // The schema parameter must be a pointer to the target struct.
-func ParseFromInterface(data interface{}, schema interface{}) error {
-	return parseFromInterfaceInternal(data, schema)
+// Options behave as in ParseFromMap.
+func ParseFromInterface(data interface{}, schema interface{}, options ...MapParseOption) error {
+	return parseFromInterfaceInternal(data, schema, options...)
 }
 
 // parseFromMapInternal parses a struct from a map[string]interface{}.
 // It iterates through struct fields, looks up values in the map using JSON tags,
 // and sets the field values with appropriate type conversion.
-func parseFromMapInternal(data map[string]interface{}, schema interface{}) error {
+func parseFromMapInternal(data map[string]interface{}, schema interface{}, options ...MapParseOption) error {
+	cfg := &mapParseConfig{}
+	for _, opt := range options {
+		opt(cfg)
+	}
+
+	lookup := newMapKeyLookup(data, cfg)
+	if err := parseFromMapWithLookup(lookup, schema); err != nil {
+		return err
+	}
+
+	if cfg.disallowUnknown {
+		if unknown := lookup.unusedKeys(); len(unknown) > 0 {
+			return fmt.Errorf("unknown keys: %s", strings.Join(unknown, ", "))
+		}
+	}
+	return nil
+}
+
+// parseFromMapWithLookup fills schema from the lookup, recursing into embedded structs.
+func parseFromMapWithLookup(lookup *mapKeyLookup, schema interface{}) error {
 	reqValue := reflect.ValueOf(schema)
 	if reqValue.Kind() != reflect.Ptr {
 		return fmt.Errorf("schema must be a pointer")
@@ -43,11 +123,11 @@ func parseFromMapInternal(data map[string]interface{}, schema interface{}) error
 				if fieldValue.IsNil() {
 					fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
 				}
-				if err := parseFromMapInternal(data, fieldValue.Interface()); err != nil {
+				if err := parseFromMapWithLookup(lookup, fieldValue.Interface()); err != nil {
 					return fmt.Errorf("embedded field %s: %w", field.Name, err)
 				}
 			} else if fieldValue.CanAddr() {
-				if err := parseFromMapInternal(data, fieldValue.Addr().Interface()); err != nil {
+				if err := parseFromMapWithLookup(lookup, fieldValue.Addr().Interface()); err != nil {
 					return fmt.Errorf("embedded field %s: %w", field.Name, err)
 				}
 			}
@@ -58,7 +138,7 @@ func parseFromMapInternal(data map[string]interface{}, schema interface{}) error
 		key := getFieldKey(field)
 
 		// Get value from map
-		if value, exists := data[key]; exists {
+		if value, exists := lookup.get(key); exists {
 			if err := setFieldValue(fieldValue, value); err != nil {
 				return fmt.Errorf("field %s: %w", key, err)
 			}
@@ -68,13 +148,130 @@ func parseFromMapInternal(data map[string]interface{}, schema interface{}) error
 	return nil
 }
 
+// mapKeyLookup resolves field keys against a data map according to a mapParseConfig
+// and records which data keys were consumed.
+type mapKeyLookup struct {
+	data   map[string]interface{}
+	cfg    *mapParseConfig
+	folded map[string]string // lower-cased key → original key, when case-insensitive
+	used   map[string]bool
+}
+
+// newMapKeyLookup prepares a lookup over data for the given configuration.
+func newMapKeyLookup(data map[string]interface{}, cfg *mapParseConfig) *mapKeyLookup {
+	l := &mapKeyLookup{data: data, cfg: cfg, used: make(map[string]bool)}
+	if cfg.caseInsensitive {
+		l.folded = make(map[string]string, len(data))
+		for k := range data {
+			l.folded[strings.ToLower(k)] = k
+		}
+	}
+	return l
+}
+
+// get returns the value for a field key, trying the exact key, the key in the configured
+// naming convention, and then case-insensitive variants of both.
+func (l *mapKeyLookup) get(key string) (interface{}, bool) {
+	candidates := []string{key}
+	if l.cfg.naming != "" {
+		if converted := convertKeyNaming(key, l.cfg.naming); converted != key {
+			candidates = append(candidates, converted)
+		}
+	}
+
+	for _, k := range candidates {
+		if v, ok := l.data[k]; ok {
+			l.used[k] = true
+			return v, true
+		}
+	}
+	if l.folded != nil {
+		for _, k := range candidates {
+			if orig, ok := l.folded[strings.ToLower(k)]; ok {
+				l.used[orig] = true
+				return l.data[orig], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// unusedKeys returns the sorted data keys that no field consumed.
+func (l *mapKeyLookup) unusedKeys() []string {
+	var unknown []string
+	for k := range l.data {
+		if !l.used[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// convertKeyNaming rewrites key in the given naming convention.
+func convertKeyNaming(key string, naming KeyNaming) string {
+	words := splitKeyWords(key)
+	if len(words) == 0 {
+		return key
+	}
+
+	switch naming {
+	case SnakeCase:
+		return strings.ToLower(strings.Join(words, "_"))
+	case KebabCase:
+		return strings.ToLower(strings.Join(words, "-"))
+	case CamelCase, PascalCase:
+		var b strings.Builder
+		for i, w := range words {
+			w = strings.ToLower(w)
+			if i == 0 && naming == CamelCase {
+				b.WriteString(w)
+				continue
+			}
+			b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+		}
+		return b.String()
+	}
+	return key
+}
+
+// splitKeyWords splits a key into words on separators and case boundaries,
+// keeping acronyms together ("userID" → [user ID], "HTTPServer" → [HTTP Server]).
+func splitKeyWords(key string) []string {
+	var words []string
+	runes := []rune(key)
+	start := 0
+	flush := func(end int) {
+		if end > start {
+			words = append(words, string(runes[start:end]))
+		}
+	}
+
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-' || r == ' ' || r == '.':
+			flush(i)
+			start = i + 1
+		case i > start && unicode.IsUpper(r):
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush(i)
+				start = i
+			}
+		}
+	}
+	flush(len(runes))
+	return words
+}
+
 // parseFromInterfaceInternal parses a struct from any interface{} (map, struct, etc.).
 // It handles different data types by converting them to a common format and then parsing.
 // Supported types include map[string]interface{}, map[string]string, and structs.
-func parseFromInterfaceInternal(data interface{}, schema interface{}) error {
+func parseFromInterfaceInternal(data interface{}, schema interface{}, options ...MapParseOption) error {
 	// Handle map[string]interface{}
 	if mapData, ok := data.(map[string]interface{}); ok {
-		return parseFromMapInternal(mapData, schema)
+		return parseFromMapInternal(mapData, schema, options...)
 	}
 
 	// Handle map[string]string
@@ -84,7 +281,7 @@ func parseFromInterfaceInternal(data interface{}, schema interface{}) error {
 		for k, v := range mapData {
 			interfaceMap[k] = v
 		}
-		return parseFromMapInternal(interfaceMap, schema)
+		return parseFromMapInternal(interfaceMap, schema, options...)
 	}
 
 	// Handle struct by converting to map
@@ -94,7 +291,7 @@ func parseFromInterfaceInternal(data interface{}, schema interface{}) error {
 	}
 
 	if dataValue.Kind() == reflect.Struct {
-		return parseFromStruct(data, schema)
+		return parseFromStruct(data, schema, options...)
 	}
 
 	return fmt.Errorf("unsupported data type: %T", data)
@@ -103,7 +300,7 @@ func parseFromInterfaceInternal(data interface{}, schema interface{}) error {
 // parseFromStruct parses from one struct to another.
 // It converts the source struct to a map using JSON tags and then parses into the target struct.
 // This is useful for copying data between structs with different field names or types.
-func parseFromStruct(data interface{}, schema interface{}) error {
+func parseFromStruct(data interface{}, schema interface{}, options ...MapParseOption) error {
 	dataValue := reflect.ValueOf(data)
 	if dataValue.Kind() == reflect.Ptr {
 		dataValue = dataValue.Elem()
@@ -126,7 +323,7 @@ func parseFromStruct(data interface{}, schema interface{}) error {
 		dataMap[key] = value
 	}
 
-	return parseFromMapInternal(dataMap, schema)
+	return parseFromMapInternal(dataMap, schema, options...)
 }
 
 // getFieldKey gets the key name for a field from json tag or field name.
@@ -141,3 +338,156 @@ func getFieldKey(field reflect.StructField) string {
 	}
 	return field.Name
 }
+
+// StructToMap converts a struct (or pointer to struct) into a map[string]interface{}.
+// It is the inverse of ParseFromMap: keys follow getFieldKey (json name, else field name),
+// fields tagged json:"-" are skipped, omitempty drops empty values, embedded structs are
+// flattened into the parent (as in the generated OpenAPI schemas) and nested structs,
+// including those inside slices and maps, are converted recursively.
+// Values implementing json.Marshaler, and time.Time, are kept as-is.
+func StructToMap(data interface{}) (map[string]interface{}, error) {
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, fmt.Errorf("data must not be nil")
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("data must be a struct, got %T", data)
+	}
+	return structToMapInternal(v), nil
+}
+
+// structToMapInternal converts a struct value into a map. Direct fields take precedence over
+// fields promoted from embedded structs, mirroring encoding/json.
+func structToMapInternal(v reflect.Value) map[string]interface{} {
+	result := make(map[string]interface{})
+	t := v.Type()
+
+	var embedded []reflect.Value
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldValue := v.Field(i)
+
+		if field.Anonymous {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && ft != reflect.TypeOf(time.Time{}) {
+				if fieldValue.Kind() == reflect.Ptr {
+					if fieldValue.IsNil() {
+						continue
+					}
+					fieldValue = fieldValue.Elem()
+				}
+				embedded = append(embedded, fieldValue)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		jsonTag := field.Tag.Get("json")
+		if jsonTag == "-" {
+			continue
+		}
+		if strings.Contains(jsonTag, ",omitempty") && isEmptyValue(fieldValue) {
+			continue
+		}
+
+		result[getFieldKey(field)] = toMapValue(fieldValue)
+	}
+
+	for _, emb := range embedded {
+		for k, val := range structToMapInternal(emb) {
+			if _, exists := result[k]; !exists {
+				result[k] = val
+			}
+		}
+	}
+
+	return result
+}
+
+// toMapValue converts a field value for StructToMap, recursing into structs, slices and maps
+// whose elements need conversion and leaving other values untouched.
+func toMapValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Type().Implements(reflect.TypeOf((*json.Marshaler)(nil)).Elem()) {
+		return v.Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return toMapValue(v.Elem())
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			return v.Interface()
+		}
+		return structToMapInternal(v)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return v.Interface()
+		}
+		if !needsMapConversion(v.Type().Elem()) {
+			return v.Interface()
+		}
+		items := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			items[i] = toMapValue(v.Index(i))
+		}
+		return items
+	case reflect.Map:
+		if v.IsNil() || v.Type().Key().Kind() != reflect.String || !needsMapConversion(v.Type().Elem()) {
+			return v.Interface()
+		}
+		m := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m[iter.Key().String()] = toMapValue(iter.Value())
+		}
+		return m
+	}
+	return v.Interface()
+}
+
+// needsMapConversion reports whether values of type t may contain structs that StructToMap must convert.
+func needsMapConversion(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return t != reflect.TypeOf(time.Time{})
+	case reflect.Interface, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// isEmptyValue reports whether v is empty in the sense of the json omitempty option.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package autofiber_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	autofiber "github.com/vuongtlt13/auto-fiber"
@@ -141,3 +142,185 @@ func TestParseFromInterface_UnsupportedType(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "unsupported data type")
 }
+
+func TestStructToMap(t *testing.T) {
+	type Base struct {
+		ID        int       `json:"id"`
+		CreatedAt time.Time `json:"createdAt"`
+	}
+	type Address struct {
+		City string `json:"city"`
+		Zip  string `json:"zip,omitempty"`
+	}
+	type User struct {
+		Base
+		Name     string            `json:"name"`
+		Nickname string            `json:"nickname,omitempty"`
+		Password string            `json:"-"`
+		Address  *Address          `json:"address"`
+		Friends  []Address         `json:"friends"`
+		Labels   map[string]string `json:"labels,omitempty"`
+		Active   bool
+		secret   string
+	}
+
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	user := &User{
+		Base:     Base{ID: 1, CreatedAt: created},
+		Name:     "John",
+		Password: "hidden",
+		Address:  &Address{City: "Hanoi"},
+		Friends:  []Address{{City: "Hue", Zip: "530000"}},
+		Active:   true,
+		secret:   "x",
+	}
+
+	m, err := autofiber.StructToMap(user)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"id":        1,
+		"createdAt": created,
+		"name":      "John",
+		"address":   map[string]interface{}{"city": "Hanoi"},
+		"friends": []interface{}{
+			map[string]interface{}{"city": "Hue", "zip": "530000"},
+		},
+		"Active": true,
+	}, m)
+}
+
+func TestStructToMap_OuterFieldWinsOverEmbedded(t *testing.T) {
+	type Base struct {
+		Name string `json:"name"`
+	}
+	type Outer struct {
+		*Base
+		Name string `json:"name"`
+	}
+
+	m, err := autofiber.StructToMap(Outer{Base: &Base{Name: "inner"}, Name: "outer"})
+	assert.NoError(t, err)
+	assert.Equal(t, "outer", m["name"])
+
+	// nil embedded pointers are skipped
+	m, err = autofiber.StructToMap(Outer{Name: "outer"})
+	assert.NoError(t, err)
+	assert.Len(t, m, 1)
+}
+
+func TestStructToMap_RoundTrip(t *testing.T) {
+	type User struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	m, err := autofiber.StructToMap(User{Name: "John", Age: 30})
+	assert.NoError(t, err)
+
+	var back User
+	assert.NoError(t, autofiber.ParseFromMap(m, &back))
+	assert.Equal(t, User{Name: "John", Age: 30}, back)
+}
+
+func TestStructToMap_InvalidInput(t *testing.T) {
+	_, err := autofiber.StructToMap(42)
+	assert.Error(t, err)
+
+	var nilPtr *struct{}
+	_, err = autofiber.StructToMap(nilPtr)
+	assert.Error(t, err)
+}
+
+func TestParseFromMap_MatchNaming(t *testing.T) {
+	type Event struct {
+		UserID    int    `json:"userId"`
+		FirstName string `json:"firstName"`
+		Kind      string `json:"kind"`
+	}
+
+	data := map[string]interface{}{
+		"user_id":    42,
+		"first_name": "John",
+		"kind":       "signup",
+	}
+
+	// Exact matching misses the snake_case keys.
+	event := &Event{}
+	assert.NoError(t, autofiber.ParseFromMap(data, event))
+	assert.Equal(t, 0, event.UserID)
+
+	event = &Event{}
+	assert.NoError(t, autofiber.ParseFromMap(data, event, autofiber.MatchNaming(autofiber.SnakeCase)))
+	assert.Equal(t, 42, event.UserID)
+	assert.Equal(t, "John", event.FirstName)
+	assert.Equal(t, "signup", event.Kind)
+}
+
+func TestParseFromMap_MatchNamingConventions(t *testing.T) {
+	type Target struct {
+		HTTPStatus int `json:"http_status"`
+	}
+
+	for naming, key := range map[autofiber.KeyNaming]string{
+		autofiber.CamelCase:  "httpStatus",
+		autofiber.PascalCase: "HttpStatus",
+		autofiber.KebabCase:  "http-status",
+		autofiber.SnakeCase:  "http_status",
+	} {
+		target := &Target{}
+		err := autofiber.ParseFromMap(map[string]interface{}{key: 200}, target, autofiber.MatchNaming(naming))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, target.HTTPStatus, string(naming))
+	}
+}
+
+func TestParseFromMap_MatchCaseInsensitive(t *testing.T) {
+	type User struct {
+		Email string `json:"email"`
+		Name  string `json:"userName"`
+	}
+
+	data := map[string]interface{}{"EMAIL": "a@b.c", "USER_NAME": "john"}
+
+	user := &User{}
+	assert.NoError(t, autofiber.ParseFromMap(data, user, autofiber.MatchCaseInsensitive()))
+	assert.Equal(t, "a@b.c", user.Email)
+	assert.Equal(t, "", user.Name)
+
+	user = &User{}
+	assert.NoError(t, autofiber.ParseFromMap(data, user,
+		autofiber.MatchCaseInsensitive(),
+		autofiber.MatchNaming(autofiber.SnakeCase),
+	))
+	assert.Equal(t, "john", user.Name)
+}
+
+func TestParseFromMap_DisallowUnknownKeys(t *testing.T) {
+	type Base struct {
+		ID int `json:"id"`
+	}
+	type User struct {
+		Base
+		Name string `json:"name"`
+	}
+
+	user := &User{}
+	err := autofiber.ParseFromMap(map[string]interface{}{"id": 1, "name": "John"}, user, autofiber.DisallowUnknownKeys())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, user.ID)
+
+	err = autofiber.ParseFromMap(map[string]interface{}{"id": 1, "zeta": 1, "alpha": 2}, &User{}, autofiber.DisallowUnknownKeys())
+	assert.Error(t, err)
+	assert.Equal(t, "unknown keys: alpha, zeta", err.Error())
+}
+
+func TestParseFromInterface_WithOptions(t *testing.T) {
+	type User struct {
+		FirstName string `json:"firstName"`
+	}
+
+	user := &User{}
+	err := autofiber.ParseFromInterface(map[string]string{"first_name": "John"}, user, autofiber.MatchNaming(autofiber.SnakeCase))
+	assert.NoError(t, err)
+	assert.Equal(t, "John", user.FirstName)
+}
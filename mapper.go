@@ -0,0 +1,573 @@
+// Package autofiber provides struct-to-struct mapping with declarative rename tags and cached plans.
+package autofiber
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// mapPlanCache stores compiled mapping plans per (source, destination) type pair.
+// Plans are built on first use; reads are lock-free on the hot path.
+var mapPlanCache sync.Map // map[mapTypePair]*mapPlan
+
+// mapConverters stores custom converters registered with RegisterConverter.
+var mapConverters sync.Map // map[mapTypePair]mapAssignFunc
+
+// mapTypePair identifies a source/destination type combination.
+type mapTypePair struct {
+	src reflect.Type
+	dst reflect.Type
+}
+
+// mapAssignFunc copies src into dst, converting as needed. dst is always settable.
+type mapAssignFunc func(dst, src reflect.Value) error
+
+// mapPlan is the compiled list of field copies for a type pair.
+// err is set when the pair cannot be mapped; it is cached so the failure is cheap to repeat.
+type mapPlan struct {
+	steps []mapStep
+	err   error
+}
+
+// mapStep copies a single (possibly nested or promoted) source field into a destination field.
+type mapStep struct {
+	key     string  // destination mapping key, used in error messages
+	srcPath [][]int // field indexes from the source root, one entry per struct hop
+	dstPath [][]int // field indexes from the destination root, one entry per struct hop
+	assign  mapAssignFunc
+}
+
+// mapField describes a mappable field reached from a struct root.
+type mapField struct {
+	key    string       // mapping key (map tag, json name or Go name)
+	goName string       // Go field name, used as a fallback match
+	path   [][]int      // field indexes from the root, one entry per struct hop
+	typ    reflect.Type // field type
+	tagged bool         // key comes from a map or json tag
+}
+
+// depth is the embedding depth of the field within its struct: 1 for fields declared directly
+// on it, plus one per embedded struct it is promoted through.
+func (f mapField) depth() int {
+	return len(f.path[len(f.path)-1])
+}
+
+// Map converts src (a struct or pointer to struct) into a new Dst.
+// Fields are matched by their mapping key: the `map` tag when present, otherwise the json
+// name (see getFieldKey), falling back to the Go field name. A destination `map` tag may
+// use dots to reach into nested source structs (e.g. `map:"address.city"`), and `map:"-"`
+// excludes a field. Nested structs, pointers, slices and maps are converted element by element,
+// and converters registered with RegisterConverter take precedence over the built-in rules.
+//
+// Example:
+//
+//	type User struct {
+//	    ID    int    `json:"id"`
+//	    Email string `json:"email"`
+//	}
+//	type UserDTO struct {
+//	    UserID int    `map:"id"`
+//	    Email  string `json:"email"`
+//	}
+//
+//	dto, err := autofiber.Map[UserDTO](user)
+func Map[Dst any](src interface{}) (*Dst, error) {
+	dst := new(Dst)
+	if err := MapInto(src, dst); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// MapInto converts src into the struct pointed to by dst using the same rules as Map.
+// Destination fields without a matching source field are left untouched. Like encoding/json, it
+// fails when a field is promoted through a nil pointer to an unexported embedded struct.
+func MapInto(src interface{}, dst interface{}) error {
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() {
+		return fmt.Errorf("destination must be a non-nil pointer")
+	}
+	dstValue = dstValue.Elem()
+	if dstValue.Kind() != reflect.Struct {
+		return fmt.Errorf("destination must point to a struct, got %s", dstValue.Type())
+	}
+
+	srcValue := reflect.ValueOf(src)
+	for srcValue.Kind() == reflect.Ptr || srcValue.Kind() == reflect.Interface {
+		if srcValue.IsNil() {
+			return fmt.Errorf("source must not be nil")
+		}
+		srcValue = srcValue.Elem()
+	}
+	if srcValue.Kind() != reflect.Struct {
+		return fmt.Errorf("source must be a struct, got %T", src)
+	}
+
+	return mapStruct(dstValue, srcValue)
+}
+
+// RegisterConverter registers fn to convert values of type S into type D whenever the mapper
+// encounters that pair, including inside slices, maps and nested structs.
+// Registering a converter invalidates previously compiled plans.
+//
+// Example:
+//
+//	autofiber.RegisterConverter(func(t time.Time) (string, error) {
+//	    return t.Format(time.RFC3339), nil
+//	})
+func RegisterConverter[S, D any](fn func(S) (D, error)) {
+	pair := mapTypePair{
+		src: reflect.TypeOf((*S)(nil)).Elem(),
+		dst: reflect.TypeOf((*D)(nil)).Elem(),
+	}
+	mapConverters.Store(pair, mapAssignFunc(func(dst, src reflect.Value) error {
+		out, err := fn(src.Interface().(S))
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(&out).Elem())
+		return nil
+	}))
+
+	// Plans capture converters at build time, so drop them all.
+	mapPlanCache.Range(func(key, _ interface{}) bool {
+		mapPlanCache.Delete(key)
+		return true
+	})
+}
+
+// mapStruct copies src into dst following the cached plan for their types.
+func mapStruct(dst, src reflect.Value) error {
+	plan := getOrBuildMapPlan(src.Type(), dst.Type())
+	if plan.err != nil {
+		return plan.err
+	}
+	for _, step := range plan.steps {
+		from, ok := resolveMapSource(src, step.srcPath)
+		if !ok {
+			continue
+		}
+		to, err := resolveMapDest(dst, step.dstPath)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", step.key, err)
+		}
+		if err := step.assign(to, from); err != nil {
+			return fmt.Errorf("field %s: %w", step.key, err)
+		}
+	}
+	return nil
+}
+
+// getOrBuildMapPlan returns (and lazily builds) the cached plan for the src → dst pair.
+func getOrBuildMapPlan(src, dst reflect.Type) *mapPlan {
+	pair := mapTypePair{src: src, dst: dst}
+	if v, ok := mapPlanCache.Load(pair); ok {
+		return v.(*mapPlan)
+	}
+	plan := buildMapPlan(src, dst)
+	mapPlanCache.Store(pair, plan)
+	return plan
+}
+
+// buildMapPlan matches destination fields to source fields and compiles an assign func for each pair.
+func buildMapPlan(src, dst reflect.Type) *mapPlan {
+	plan := &mapPlan{}
+
+	srcFields := collectMapFields(src, nil)
+	srcByKey := make(map[string]mapField, len(srcFields))
+	srcByName := make(map[string]mapField, len(srcFields))
+	for _, f := range srcFields {
+		srcByKey[f.key] = f
+		if existing, ok := srcByName[f.goName]; !ok || f.depth() < existing.depth() {
+			srcByName[f.goName] = f
+		}
+	}
+
+	for _, df := range collectMapFields(dst, nil) {
+		var sf mapField
+		var found bool
+		if strings.Contains(df.key, ".") {
+			sf, found = lookupMapPath(src, df.key)
+		} else if sf, found = srcByKey[df.key]; !found {
+			sf, found = srcByName[df.goName]
+		}
+		if !found {
+			continue
+		}
+
+		assign, err := buildMapAssign(df.typ, sf.typ)
+		if err != nil {
+			plan.err = fmt.Errorf("field %s: %w", df.key, err)
+			return plan
+		}
+		plan.steps = append(plan.steps, mapStep{
+			key:     df.key,
+			srcPath: sf.path,
+			dstPath: df.path,
+			assign:  assign,
+		})
+	}
+	return plan
+}
+
+// collectMapFields lists the exported fields of t with their mapping keys, flattening embedded
+// structs the same way the json encoder promotes their fields: when several fields share a key,
+// the shallowest one wins, a tagged one breaking ties at the same depth, and remaining ties hide
+// the key altogether.
+func collectMapFields(t reflect.Type, prefix [][]int) []mapField {
+	fields := collectEmbeddedMapFields(t, prefix)
+	byKey := make(map[string][]mapField, len(fields))
+	for _, f := range fields {
+		byKey[f.key] = append(byKey[f.key], f)
+	}
+	dominant := make([]mapField, 0, len(fields))
+	for _, f := range fields {
+		if winner, ok := dominantMapField(byKey[f.key]); ok && sameMapPath(winner.path, f.path) {
+			dominant = append(dominant, f)
+		}
+	}
+	return dominant
+}
+
+// dominantMapField picks the field a key refers to among the fields sharing it, as
+// encoding/json does, reporting false when the key is ambiguous.
+func dominantMapField(fields []mapField) (mapField, bool) {
+	minDepth := fields[0].depth()
+	for _, f := range fields[1:] {
+		if f.depth() < minDepth {
+			minDepth = f.depth()
+		}
+	}
+	var shallowest, tagged []mapField
+	for _, f := range fields {
+		if f.depth() == minDepth {
+			shallowest = append(shallowest, f)
+			if f.tagged {
+				tagged = append(tagged, f)
+			}
+		}
+	}
+	switch {
+	case len(shallowest) == 1:
+		return shallowest[0], true
+	case len(tagged) == 1:
+		return tagged[0], true
+	}
+	return mapField{}, false
+}
+
+// sameMapPath reports whether a and b lead to the same field.
+func sameMapPath(a, b [][]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for j := range a[i] {
+			if a[i][j] != b[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// collectEmbeddedMapFields lists every exported field of t reachable through embedded structs,
+// before shadowed fields are removed.
+func collectEmbeddedMapFields(t reflect.Type, prefix [][]int) []mapField {
+	var fields []mapField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("map")
+		if tag == "-" {
+			continue
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && tag == "" && ft.Kind() == reflect.Struct && ft != reflect.TypeOf(time.Time{}) {
+			fields = append(fields, collectEmbeddedMapFields(ft, appendMapPath(prefix, []int{i}))...)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		key := tag
+		tagged := tag != ""
+		if key == "" {
+			if f.Tag.Get("json") == "-" {
+				continue
+			}
+			key = getFieldKey(f)
+			jsonName, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			tagged = jsonName != ""
+		}
+		fields = append(fields, mapField{
+			key:    key,
+			goName: f.Name,
+			path:   appendMapPath(prefix, []int{i}),
+			typ:    f.Type,
+			tagged: tagged,
+		})
+	}
+	return fields
+}
+
+// lookupMapPath resolves a dotted mapping key (e.g. "address.city") against the source type.
+func lookupMapPath(t reflect.Type, dotted string) (mapField, bool) {
+	var path [][]int
+	var current mapField
+	for _, part := range strings.Split(dotted, ".") {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return mapField{}, false
+		}
+		found := false
+		for _, f := range collectMapFields(t, nil) {
+			if f.key == part || f.goName == part {
+				current = f
+				found = true
+				break
+			}
+		}
+		if !found {
+			return mapField{}, false
+		}
+		// Each segment starts a new hop so nil pointers between segments are handled.
+		path = append(path, flattenMapPath(current.path))
+		t = current.typ
+	}
+	current.path = path
+	current.key = dotted
+	return current, true
+}
+
+// appendMapPath returns a copy of prefix with index appended to its last hop.
+// Embedded fields extend the current hop rather than starting a new one.
+func appendMapPath(prefix [][]int, index []int) [][]int {
+	if len(prefix) == 0 {
+		return [][]int{index}
+	}
+	out := make([][]int, len(prefix))
+	copy(out, prefix)
+	last := make([]int, 0, len(prefix[len(prefix)-1])+len(index))
+	last = append(last, prefix[len(prefix)-1]...)
+	out[len(out)-1] = append(last, index...)
+	return out
+}
+
+// flattenMapPath joins the hops of a single-struct path into one index slice.
+func flattenMapPath(path [][]int) []int {
+	var out []int
+	for _, hop := range path {
+		out = append(out, hop...)
+	}
+	return out
+}
+
+// resolveMapSource walks src along path, returning false when a nil pointer is encountered.
+func resolveMapSource(src reflect.Value, path [][]int) (reflect.Value, bool) {
+	v := src
+	for hopIdx, hop := range path {
+		if hopIdx > 0 {
+			for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+				if v.IsNil() {
+					return reflect.Value{}, false
+				}
+				v = v.Elem()
+			}
+		}
+		for _, idx := range hop {
+			if v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					return reflect.Value{}, false
+				}
+				v = v.Elem()
+			}
+			v = v.Field(idx)
+		}
+	}
+	return v, true
+}
+
+// resolveMapDest walks dst along path, allocating nil embedded pointers on the way. Like
+// encoding/json, it fails when such a pointer is unexported and cannot be set.
+func resolveMapDest(dst reflect.Value, path [][]int) (reflect.Value, error) {
+	v := dst
+	for _, hop := range path {
+		for _, idx := range hop {
+			if v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					if !v.CanSet() {
+						return reflect.Value{}, fmt.Errorf("cannot set embedded pointer to unexported struct %s", v.Type().Elem())
+					}
+					v.Set(reflect.New(v.Type().Elem()))
+				}
+				v = v.Elem()
+			}
+			v = v.Field(idx)
+		}
+	}
+	return v, nil
+}
+
+// buildMapAssign compiles the conversion from src to dst values.
+// Rules are tried in order: registered converter, direct assignment, pointers, structs,
+// slices/arrays, maps and finally Go value conversion between compatible basic kinds.
+func buildMapAssign(dst, src reflect.Type) (mapAssignFunc, error) {
+	if conv, ok := mapConverters.Load(mapTypePair{src: src, dst: dst}); ok {
+		return conv.(mapAssignFunc), nil
+	}
+
+	if src.AssignableTo(dst) {
+		return func(to, from reflect.Value) error {
+			to.Set(from)
+			return nil
+		}, nil
+	}
+
+	switch {
+	case dst.Kind() == reflect.Ptr:
+		elemSrc := src
+		if src.Kind() == reflect.Ptr {
+			elemSrc = src.Elem()
+		}
+		inner, err := buildMapAssign(dst.Elem(), elemSrc)
+		if err != nil {
+			return nil, err
+		}
+		return func(to, from reflect.Value) error {
+			if from.Kind() == reflect.Ptr {
+				if from.IsNil() {
+					to.Set(reflect.Zero(to.Type()))
+					return nil
+				}
+				from = from.Elem()
+			}
+			target := reflect.New(to.Type().Elem())
+			if err := inner(target.Elem(), from); err != nil {
+				return err
+			}
+			to.Set(target)
+			return nil
+		}, nil
+
+	case src.Kind() == reflect.Ptr:
+		inner, err := buildMapAssign(dst, src.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return func(to, from reflect.Value) error {
+			if from.IsNil() {
+				return nil
+			}
+			return inner(to, from.Elem())
+		}, nil
+
+	case dst.Kind() == reflect.Struct && src.Kind() == reflect.Struct &&
+		dst != reflect.TypeOf(time.Time{}) && src != reflect.TypeOf(time.Time{}):
+		// Resolved at call time so self-referencing types don't recurse forever here.
+		return func(to, from reflect.Value) error {
+			return mapStruct(to, from)
+		}, nil
+
+	case (dst.Kind() == reflect.Slice || dst.Kind() == reflect.Array) &&
+		(src.Kind() == reflect.Slice || src.Kind() == reflect.Array):
+		inner, err := buildMapAssign(dst.Elem(), src.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return func(to, from reflect.Value) error {
+			if from.Kind() == reflect.Slice && from.IsNil() {
+				to.Set(reflect.Zero(to.Type()))
+				return nil
+			}
+			n := from.Len()
+			if to.Kind() == reflect.Slice {
+				to.Set(reflect.MakeSlice(to.Type(), n, n))
+			} else if n > to.Len() {
+				n = to.Len()
+			}
+			for i := 0; i < n; i++ {
+				if err := inner(to.Index(i), from.Index(i)); err != nil {
+					return fmt.Errorf("[%d]: %w", i, err)
+				}
+			}
+			return nil
+		}, nil
+
+	case dst.Kind() == reflect.Map && src.Kind() == reflect.Map:
+		keyAssign, err := buildMapAssign(dst.Key(), src.Key())
+		if err != nil {
+			return nil, err
+		}
+		elemAssign, err := buildMapAssign(dst.Elem(), src.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return func(to, from reflect.Value) error {
+			if from.IsNil() {
+				to.Set(reflect.Zero(to.Type()))
+				return nil
+			}
+			out := reflect.MakeMapWithSize(to.Type(), from.Len())
+			iter := from.MapRange()
+			for iter.Next() {
+				k := reflect.New(to.Type().Key()).Elem()
+				if err := keyAssign(k, iter.Key()); err != nil {
+					return err
+				}
+				e := reflect.New(to.Type().Elem()).Elem()
+				if err := elemAssign(e, iter.Value()); err != nil {
+					return fmt.Errorf("[%v]: %w", iter.Key().Interface(), err)
+				}
+				out.SetMapIndex(k, e)
+			}
+			to.Set(out)
+			return nil
+		}, nil
+	}
+
+	if isMapConvertible(src, dst) {
+		return func(to, from reflect.Value) error {
+			to.Set(from.Convert(to.Type()))
+			return nil
+		}, nil
+	}
+
+	return nil, fmt.Errorf("cannot map %s to %s (register a converter)", src, dst)
+}
+
+// isMapConvertible reports whether src converts to dst without surprises: numeric to numeric,
+// or between types sharing the same underlying basic kind (e.g. a named string type).
+// Go's int → string rune conversion is deliberately excluded.
+func isMapConvertible(src, dst reflect.Type) bool {
+	if !src.ConvertibleTo(dst) {
+		return false
+	}
+	if isNumericKind(src.Kind()) && isNumericKind(dst.Kind()) {
+		return true
+	}
+	return src.Kind() == dst.Kind() && src.Kind() != reflect.Struct
+}
+
+// isNumericKind reports whether k is an integer or floating point kind.
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
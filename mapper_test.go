@@ -0,0 +1,233 @@
+package autofiber_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	autofiber "github.com/vuongtlt13/auto-fiber"
+)
+
+func TestMap_RenameAndJsonMatching(t *testing.T) {
+	type User struct {
+		ID       int    `json:"id"`
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	type UserDTO struct {
+		UserID   int    `map:"id"`
+		Email    string `json:"email"`
+		Password string `map:"-"`
+	}
+
+	dto, err := autofiber.Map[UserDTO](User{ID: 7, Email: "a@b.c", Password: "secret"})
+	assert.NoError(t, err)
+	assert.Equal(t, 7, dto.UserID)
+	assert.Equal(t, "a@b.c", dto.Email)
+	assert.Empty(t, dto.Password)
+}
+
+func TestMap_NestedSlicesAndPointers(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+	}
+	type Order struct {
+		ID    int     `json:"id"`
+		Total float32 `json:"total"`
+	}
+	type User struct {
+		Name    string   `json:"name"`
+		Address *Address `json:"address"`
+		Orders  []Order  `json:"orders"`
+		Tags    map[string]Order
+	}
+
+	type AddressDTO struct {
+		City string `json:"city"`
+	}
+	type OrderDTO struct {
+		ID    int64   `json:"id"`
+		Total float64 `json:"total"`
+	}
+	type UserDTO struct {
+		Name    string              `json:"name"`
+		Address AddressDTO          `json:"address"`
+		City    string              `map:"address.city"`
+		Orders  []*OrderDTO         `json:"orders"`
+		Tags    map[string]OrderDTO `json:"Tags"`
+	}
+
+	src := &User{
+		Name:    "John",
+		Address: &Address{City: "Hanoi"},
+		Orders:  []Order{{ID: 1, Total: 1.5}, {ID: 2, Total: 3}},
+		Tags:    map[string]Order{"first": {ID: 1}},
+	}
+
+	dto, err := autofiber.Map[UserDTO](src)
+	assert.NoError(t, err)
+	assert.Equal(t, "John", dto.Name)
+	assert.Equal(t, "Hanoi", dto.Address.City)
+	assert.Equal(t, "Hanoi", dto.City)
+	assert.Len(t, dto.Orders, 2)
+	assert.Equal(t, int64(2), dto.Orders[1].ID)
+	assert.Equal(t, float64(3), dto.Orders[1].Total)
+	assert.Equal(t, int64(1), dto.Tags["first"].ID)
+
+	// Nil source pointers leave destination fields untouched.
+	dto, err = autofiber.Map[UserDTO](User{Name: "Jane"})
+	assert.NoError(t, err)
+	assert.Equal(t, "", dto.City)
+	assert.Nil(t, dto.Orders)
+}
+
+func TestMap_EmbeddedFieldsAreFlattened(t *testing.T) {
+	type Base struct {
+		ID int `json:"id"`
+	}
+	type Model struct {
+		*Base
+		Name string `json:"name"`
+	}
+	type DTO struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	dto, err := autofiber.Map[DTO](Model{Base: &Base{ID: 3}, Name: "x"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, dto.ID)
+
+	var back Model
+	assert.NoError(t, autofiber.MapInto(dto, &back))
+	assert.NotNil(t, back.Base)
+	assert.Equal(t, 3, back.ID)
+	assert.Equal(t, "x", back.Name)
+}
+
+type mapBase struct {
+	ID int `json:"id"`
+}
+
+type mapModel struct {
+	*mapBase
+	Name string `json:"name"`
+}
+
+func TestMap_UnexportedEmbeddedPointer(t *testing.T) {
+	type DTO struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	dto, err := autofiber.Map[DTO](mapModel{mapBase: &mapBase{ID: 3}, Name: "x"})
+	assert.NoError(t, err)
+	assert.Equal(t, DTO{ID: 3, Name: "x"}, *dto)
+
+	// A nil unexported embedded pointer cannot be allocated, as with encoding/json.
+	var model mapModel
+	err = autofiber.MapInto(DTO{ID: 1}, &model)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "field id")
+	assert.Contains(t, err.Error(), "unexported")
+
+	// An allocated one is filled.
+	model = mapModel{mapBase: &mapBase{}}
+	assert.NoError(t, autofiber.MapInto(DTO{ID: 1, Name: "y"}, &model))
+	assert.Equal(t, 1, model.ID)
+	assert.Equal(t, "y", model.Name)
+}
+
+func TestMap_ShadowedEmbeddedFields(t *testing.T) {
+	type Base struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	type Audit struct {
+		Name string `map:"name"`
+	}
+	type Outer struct {
+		ID int `json:"id"`
+		Base
+		Audit
+	}
+	type Dst struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	// The outer field shadows the promoted one; promoted fields at the same depth cancel out.
+	dst, err := autofiber.Map[Dst](Outer{ID: 1, Base: Base{ID: 2, Name: "base"}, Audit: Audit{Name: "audit"}})
+	assert.NoError(t, err)
+	assert.Equal(t, Dst{ID: 1}, *dst)
+
+	// The same rule picks the destination field: only the outer one is written.
+	var back Outer
+	assert.NoError(t, autofiber.MapInto(Dst{ID: 3, Name: "x"}, &back))
+	assert.Equal(t, Outer{ID: 3}, back)
+
+	// A tagged field wins a tie at the same depth.
+	type Plain struct{ Name string }
+	type Labeled struct {
+		Name string `json:"Name"`
+	}
+	type Tied struct {
+		Plain
+		Labeled
+	}
+	type Named struct{ Name string }
+	named, err := autofiber.Map[Named](Tied{Plain: Plain{Name: "plain"}, Labeled: Labeled{Name: "labeled"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "labeled", named.Name)
+}
+
+func TestMap_CustomConverter(t *testing.T) {
+	type Event struct {
+		At time.Time `json:"at"`
+	}
+	type EventDTO struct {
+		At string `json:"at"`
+	}
+
+	// Without a converter the pair is rejected.
+	_, err := autofiber.Map[EventDTO](Event{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "register a converter")
+
+	autofiber.RegisterConverter(func(t time.Time) (string, error) {
+		return strconv.Itoa(t.Year()), nil
+	})
+
+	dto, err := autofiber.Map[EventDTO](Event{At: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)})
+	assert.NoError(t, err)
+	assert.Equal(t, "2024", dto.At)
+}
+
+func TestMap_InvalidArguments(t *testing.T) {
+	type DTO struct {
+		ID int `json:"id"`
+	}
+
+	_, err := autofiber.Map[DTO](42)
+	assert.Error(t, err)
+
+	var nilPtr *DTO
+	_, err = autofiber.Map[DTO](nilPtr)
+	assert.Error(t, err)
+
+	assert.Error(t, autofiber.MapInto(DTO{}, DTO{}))
+}
+
+func TestMap_IncompatibleTypes(t *testing.T) {
+	type Src struct {
+		ID int `json:"id"`
+	}
+	type Dst struct {
+		ID string `json:"id"`
+	}
+
+	_, err := autofiber.Map[Dst](Src{ID: 65})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "field id")
+}
@@ -2,6 +2,7 @@
 package autofiber
 
 import (
+	"errors"
 	"reflect"
 
 	"github.com/go-playground/validator/v10"
@@ -35,7 +36,7 @@ func AutoParseRequest(schema interface{}, customValidator *validator.Validate) f
 			return err
 		}
 
-		if err := customValidator.Struct(req); err != nil {
+		if err := validateRequest(c, req, customValidator); err != nil {
 			return err
 		}
 
@@ -44,9 +45,34 @@ func AutoParseRequest(schema interface{}, customValidator *validator.Validate) f
 	}
 }
 
+// validateRequest runs tag validation and then the schema's RequestValidator hook, if any.
+// A hook failure is returned as a requestValidationError carrying any tag failures too.
+func validateRequest(c *fiber.Ctx, req interface{}, v *validator.Validate) error {
+	tagErr := v.Struct(req)
+	hook, ok := req.(RequestValidator)
+	if !ok {
+		return tagErr
+	}
+	var tagErrs validator.ValidationErrors
+	if tagErr != nil && !errors.As(tagErr, &tagErrs) {
+		// Not a field failure (e.g. invalid validation target); report it as-is.
+		return tagErr
+	}
+
+	hookErr := hook.Validate(c)
+	if fieldErrs, isFieldErrs := hookErr.(FieldErrors); isFieldErrs && len(fieldErrs) == 0 {
+		hookErr = nil
+	}
+	if hookErr == nil {
+		return tagErr
+	}
+	return &requestValidationError{tags: tagErrs, hook: hookErr}
+}
+
 // ValidateAndJSON validates response data and returns JSON.
 // If response validation is configured, it validates the data against the response schema
-// before returning the JSON response. If validation fails, it returns an error response.
+// before returning the JSON response. If validation fails, it returns a ValidationResponseError
+// whose Details list every failing field with its JSON path (e.g. "data.items[1].email").
 // If no validation is configured, it simply returns the JSON response.
 func ValidateAndJSON(c *fiber.Ctx, data interface{}) error {
 	schema := c.Locals("response_schema")
@@ -57,20 +83,27 @@ func ValidateAndJSON(c *fiber.Ctx, data interface{}) error {
 	}
 
 	if v, ok := validatorInstance.(*validator.Validate); ok {
-		if err := validateResponseData(data, schema, v); err != nil {
-			return &ValidationResponseError{
-				Message: "Response validation failed",
-				Details: []FieldErrorDetail{{
-					Field:   "response",
-					Message: err.Error(),
-				}},
-			}
+		if verr := validateResponse(data, schema, v); verr != nil {
+			return verr
 		}
 	}
 
 	return c.JSON(data)
 }
 
+// validateResponse validates data against schema and wraps any failure in a ValidationResponseError.
+func validateResponse(data interface{}, schema interface{}, v *validator.Validate) *ValidationResponseError {
+	if err := validateResponseData(data, schema, v); err != nil {
+		return &ValidationResponseError{
+			Message: "Response validation failed",
+			Code:    CodeResponseValidationFailed,
+			Details: responseErrorDetails(err),
+			Status:  fiber.StatusInternalServerError,
+		}
+	}
+	return nil
+}
+
 // GetParsedRequest retrieves the parsed request from context.
 // This function extracts the parsed request data that was stored by AutoParseRequest middleware.
 // It returns nil if no parsed request is found or if the type assertion fails.
@@ -3,6 +3,7 @@ package autofiber_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -707,3 +708,99 @@ func TestWithResponseSchema_GenericAPIResponse(t *testing.T) {
 		assert.Contains(t, dataSchema.Properties, "name")
 	}
 }
+
+// bookingRequest exercises the RequestValidator hook with a cross-field rule.
+type bookingRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Email string `json:"email"`
+	Phone string `json:"phone"`
+}
+
+func (r *bookingRequest) Validate(c *fiber.Ctx) error {
+	var errs autofiber.FieldErrors
+	if r.End <= r.Start {
+		errs = append(errs, autofiber.FieldErrorDetail{Field: "end", Message: "end must be after start", Tag: "after"})
+	}
+	if r.Email == "" && r.Phone == "" {
+		errs = append(errs, autofiber.FieldErrorDetail{Field: "email", Message: "email or phone is required", Tag: "required_without"})
+	}
+	return errs
+}
+
+func TestAutoParseRequest_ValidateHook(t *testing.T) {
+	handler := func(c *fiber.Ctx, req *bookingRequest) (interface{}, error) {
+		return req, nil
+	}
+	post := func(body string) (*http.Response, autofiber.ValidationRequestError) {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+		resp := testAutoParseRequest(t, bookingRequest{}, handler, req)
+		var payload autofiber.ValidationRequestError
+		_ = json.NewDecoder(resp.Body).Decode(&payload)
+		return resp, payload
+	}
+
+	resp, _ := post(`{"name":"a","start":1,"end":2,"phone":"123"}`)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Hook failures are reported as field details.
+	resp, payload := post(`{"name":"a","start":2,"end":1,"phone":"123"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Equal(t, "Validation failed", payload.Message)
+	if assert.Len(t, payload.Details, 1) {
+		assert.Equal(t, "end", payload.Details[0].Field)
+		assert.Equal(t, "after", payload.Details[0].Tag)
+	}
+
+	// Tag failures and hook failures are merged.
+	resp, payload = post(`{"start":2,"end":1}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	var fields []string
+	for _, d := range payload.Details {
+		fields = append(fields, d.Field)
+	}
+	assert.Equal(t, []string{"name", "end", "email"}, fields)
+}
+
+// slotRequest returns a plain error from its Validate hook.
+type slotRequest struct {
+	Slot string `json:"slot"`
+}
+
+func (r *slotRequest) Validate(c *fiber.Ctx) error {
+	if r.Slot == "taken" {
+		return errors.New("slot is no longer available")
+	}
+	return nil
+}
+
+func TestAutoParseRequest_ValidateHook_PlainError(t *testing.T) {
+	app := fiber.New()
+	var got error
+	app.Post("/", func(c *fiber.Ctx) error {
+		got = autofiber.AutoParseRequest(slotRequest{}, nil)(c)
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{"slot":"taken"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	_, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.EqualError(t, got, "slot is no longer available")
+
+	handler := func(c *fiber.Ctx, req *slotRequest) (interface{}, error) {
+		return req, nil
+	}
+	req = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(`{"slot":"taken"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp := testAutoParseRequest(t, slotRequest{}, handler, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	var payload autofiber.ValidationRequestError
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&payload))
+	if assert.Len(t, payload.Details, 1) {
+		assert.Equal(t, "slot is no longer available", payload.Details[0].Message)
+		assert.Equal(t, "validate", payload.Details[0].Tag)
+	}
+}
@@ -2,6 +2,8 @@
 package autofiber
 
 import (
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -51,3 +53,155 @@ func WithJwtAuth() RouteOption {
 		opts.RequireJWTAuth = true
 	}
 }
+
+// WithRouteResponseValidation overrides the app-level response validation mode for this route.
+func WithRouteResponseValidation(mode ResponseValidationMode) RouteOption {
+	return func(opts *RouteOptions) {
+		opts.ResponseValidation = &mode
+	}
+}
+
+// WithErrors documents the error statuses this route can respond with (e.g. 404, 409),
+// replacing the default 400/500 pair in the generated OpenAPI responses.
+//
+// Example:
+//
+//	app.Get("/users/:id", getUser,
+//	    autofiber.WithRequestSchema(GetUserRequest{}),
+//	    autofiber.WithErrors(fiber.StatusNotFound, fiber.StatusUnprocessableEntity),
+//	)
+func WithErrors(statuses ...int) RouteOption {
+	return func(opts *RouteOptions) {
+		opts.Errors = append(opts.Errors, statuses...)
+	}
+}
+
+// WithRouteErrorHandler sets an error handler for this route. It receives every parse, validation
+// and handler error of the route and takes precedence over the group's and the app's error handling.
+//
+// Example:
+//
+//	app.Post("/admin/users", createUser,
+//	    autofiber.WithRequestSchema(CreateUserRequest{}),
+//	    autofiber.WithRouteErrorHandler(func(c *fiber.Ctx, err error) error {
+//	        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"admin_error": err.Error()})
+//	    }),
+//	)
+func WithRouteErrorHandler(fn func(*fiber.Ctx, error) error) RouteOption {
+	return func(opts *RouteOptions) {
+		opts.ErrorHandler = fn
+	}
+}
+
+// WithStatus sets the success status code of the route (default 200), used for the response and
+// as the success entry of the generated responses map. A handler can still override it per
+// request by returning a Response.
+//
+// Example:
+//
+//	app.Post("/users", createUser,
+//	    autofiber.WithRequestSchema(CreateUserRequest{}),
+//	    autofiber.WithResponseSchema(User{}),
+//	    autofiber.WithStatus(fiber.StatusCreated),
+//	)
+func WithStatus(status int) RouteOption {
+	return func(opts *RouteOptions) {
+		opts.Status = status
+	}
+}
+
+// WithResponseHeader documents a header sent with the route's success response, such as the
+// Location header a handler sets through Response.Headers.
+func WithResponseHeader(name, description string) RouteOption {
+	return func(opts *RouteOptions) {
+		if opts.ResponseHeaders == nil {
+			opts.ResponseHeaders = make(map[string]string)
+		}
+		opts.ResponseHeaders[name] = description
+	}
+}
+
+// WithTimeout sets a deadline on the context passed to a context-first handler
+// (func(context.Context, *T) (R, error)). Handlers that take *fiber.Ctx are not affected.
+//
+// Example:
+//
+//	app.Get("/reports/:id", reports.Get,
+//	    autofiber.WithRequestSchema(GetReportRequest{}),
+//	    autofiber.WithTimeout(2*time.Second),
+//	)
+func WithTimeout(d time.Duration) RouteOption {
+	return func(opts *RouteOptions) {
+		opts.Timeout = d
+	}
+}
+
+// WithProduces restricts the media types the route's response can be encoded in to the given
+// registered encoders (see WithEncoders), in order of preference. Requests accepting none of
+// them get 406 Not Acceptable.
+//
+// Example:
+//
+//	app.Get("/reports", listReports,
+//	    autofiber.WithResponseSchema([]Report{}),
+//	    autofiber.WithProduces("text/csv", fiber.MIMEApplicationJSON),
+//	)
+func WithProduces(mediaTypes ...string) RouteOption {
+	return func(opts *RouteOptions) {
+		opts.Produces = append(opts.Produces, mediaTypes...)
+	}
+}
+
+// WithFields lets clients select response fields with ?fields=id,name,organization.name. Paths use
+// json field names: a dotted path selects inside nested objects, applying to every element of
+// arrays, and naming an object selects it whole. With a response schema, unknown paths are
+// rejected with 400 and the allowed ones are documented on the fields parameter. Only JSON
+// responses are projected.
+//
+// Example:
+//
+//	app.Get("/users/:id", getUser, autofiber.WithResponseSchema(User{}), autofiber.WithFields())
+func WithFields() RouteOption {
+	return func(opts *RouteOptions) {
+		opts.Fields = true
+	}
+}
+
+// WithExpand lets clients request the named expansions with ?expand=organization,manager; requests
+// naming anything else are rejected with 400. The handler reads the accepted expansions from the
+// request schema's Expand field, or with RequestedExpand.
+//
+// Example:
+//
+//	app.Get("/users/:id", getUser,
+//	    autofiber.WithRequestSchema(GetUserRequest{}),
+//	    autofiber.WithExpand("organization", "manager"),
+//	)
+func WithExpand(names ...string) RouteOption {
+	return func(opts *RouteOptions) {
+		opts.Expand = append(opts.Expand, names...)
+	}
+}
+
+// WithResponse declares the response the route sends with status: its body schema (nil when it
+// has none) and description. A route may declare several, e.g. 200 User and 202 Job; the body a
+// handler returns is validated against the schema declared for the status it is sent with
+// (see Response), falling back to WithResponseSchema for undeclared statuses. Each declared
+// response is documented under its status, replacing the generated one; unless WithStatus is
+// set, declaring any 2xx status also drops the generated 200 success response.
+//
+// Example:
+//
+//	app.Post("/exports", createExport,
+//	    autofiber.WithResponse(fiber.StatusOK, Export{}, "Export ready"),
+//	    autofiber.WithResponse(fiber.StatusAccepted, Job{}, "Export queued"),
+//	    autofiber.WithResponse(fiber.StatusNotFound, ErrorBody{}, "Unknown dataset"),
+//	)
+func WithResponse(status int, schema interface{}, description string) RouteOption {
+	return func(opts *RouteOptions) {
+		if opts.Responses == nil {
+			opts.Responses = make(map[int]RouteResponse)
+		}
+		opts.Responses[status] = RouteResponse{Schema: schema, Description: description}
+	}
+}
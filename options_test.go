@@ -81,3 +81,59 @@ func TestWithTags_Single(t *testing.T) {
 
 	assert.Equal(t, []string{tag}, opts.Tags)
 }
+
+func TestWithRouteResponseValidation(t *testing.T) {
+	option := autofiber.WithRouteResponseValidation(autofiber.ReportResponseValidation())
+	opts := &autofiber.RouteOptions{}
+
+	option(opts)
+
+	assert.NotNil(t, opts.ResponseValidation)
+	assert.Equal(t, autofiber.ReportResponseValidation(), *opts.ResponseValidation)
+}
+
+func TestWithErrors(t *testing.T) {
+	opts := &autofiber.RouteOptions{}
+
+	autofiber.WithErrors(404, 409)(opts)
+	autofiber.WithErrors(403)(opts)
+
+	assert.Equal(t, []int{404, 409, 403}, opts.Errors)
+}
+
+func TestWithRouteErrorHandler(t *testing.T) {
+	opts := &autofiber.RouteOptions{}
+
+	autofiber.WithRouteErrorHandler(func(c *fiber.Ctx, err error) error { return nil })(opts)
+
+	assert.NotNil(t, opts.ErrorHandler)
+}
+
+func TestWithStatus(t *testing.T) {
+	opts := &autofiber.RouteOptions{}
+
+	autofiber.WithStatus(201)(opts)
+
+	assert.Equal(t, 201, opts.Status)
+}
+
+func TestWithResponseHeader(t *testing.T) {
+	opts := &autofiber.RouteOptions{}
+
+	autofiber.WithResponseHeader("Location", "URL of the resource")(opts)
+	autofiber.WithResponseHeader("ETag", "Resource version")(opts)
+
+	assert.Equal(t, map[string]string{"Location": "URL of the resource", "ETag": "Resource version"}, opts.ResponseHeaders)
+}
+
+func TestWithResponse(t *testing.T) {
+	opts := &autofiber.RouteOptions{}
+
+	autofiber.WithResponse(200, "user", "The user")(opts)
+	autofiber.WithResponse(404, nil, "")(opts)
+
+	assert.Equal(t, map[int]autofiber.RouteResponse{
+		200: {Schema: "user", Description: "The user"},
+		404: {},
+	}, opts.Responses)
+}
@@ -2,6 +2,8 @@
 package autofiber
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -106,13 +108,15 @@ func parseFromMultipleSources(c *fiber.Ctx, req interface{}) error {
 					Field:   "body",
 					Source:  "body",
 					Message: "Request body is required for JSON requests",
+					Code:    CodeInvalidBody,
 				}
 			}
 			if err := c.BodyParser(req); err != nil {
 				return &ParseError{
-					Field:   "body",
+					Field:   bodyErrorField(err),
 					Source:  "body",
 					Message: "Invalid request body: " + err.Error(),
+					Code:    CodeInvalidBody,
 				}
 			}
 		} else if len(c.Body()) > 0 {
@@ -121,6 +125,7 @@ func parseFromMultipleSources(c *fiber.Ctx, req interface{}) error {
 					Field:   "body",
 					Source:  "body",
 					Message: "Invalid request body: " + err.Error(),
+					Code:    CodeInvalidBody,
 				}
 			}
 		}
@@ -276,8 +281,9 @@ func convertDefaultValue(defaultStr string, fieldType reflect.Type) interface{}
 // and sets the value in the struct. Handles required and default values.
 func parseFieldFromSource(c *fiber.Ctx, fieldInfo *FieldInfo, fieldValue reflect.Value) error {
 	var value interface{}
+	source := fieldInfo.Source
 
-	switch fieldInfo.Source {
+	switch source {
 	case Query:
 		value = c.Query(fieldInfo.Key)
 
@@ -295,11 +301,13 @@ func parseFieldFromSource(c *fiber.Ctx, fieldInfo *FieldInfo, fieldValue reflect
 
 	case Auto:
 		// Smart parsing: try path first, then query.
-		if pathValue := c.Params(fieldInfo.Key); pathValue != "" {
-			value = pathValue
-		} else if queryValue := c.Query(fieldInfo.Key); queryValue != "" {
-			value = queryValue
-		} else {
+		source = resolveAutoSource(c, fieldInfo.Key)
+		switch source {
+		case Path:
+			value = c.Params(fieldInfo.Key)
+		case Query:
+			value = c.Query(fieldInfo.Key)
+		default:
 			// Body will be handled by BodyParser above.
 			return nil
 		}
@@ -312,8 +320,9 @@ func parseFieldFromSource(c *fiber.Ctx, fieldInfo *FieldInfo, fieldValue reflect
 	if fieldInfo.Required && (value == "" || value == nil) {
 		return &ParseError{
 			Field:   fieldInfo.Key,
-			Source:  string(fieldInfo.Source),
+			Source:  string(source),
 			Message: "field is required",
+			Code:    CodeMissingField,
 		}
 	}
 
@@ -327,8 +336,9 @@ func parseFieldFromSource(c *fiber.Ctx, fieldInfo *FieldInfo, fieldValue reflect
 		if err := setFieldValue(fieldValue, value); err != nil {
 			return &ParseError{
 				Field:   fieldInfo.Key,
-				Source:  string(fieldInfo.Source),
+				Source:  string(source),
 				Message: err.Error(),
+				Code:    CodeInvalidValue,
 			}
 		}
 	}
@@ -336,6 +346,55 @@ func parseFieldFromSource(c *fiber.Ctx, fieldInfo *FieldInfo, fieldValue reflect
 	return nil
 }
 
+// resolveAutoSource reports where an Auto field's value comes from for this request:
+// the path when a route parameter is set, then the query string, otherwise the body.
+func resolveAutoSource(c *fiber.Ctx, key string) ParseSource {
+	if c.Params(key) != "" {
+		return Path
+	}
+	if c.Query(key) != "" {
+		return Query
+	}
+	return Body
+}
+
+// bodyErrorField returns the JSON path of the field a body decoding error refers to,
+// or "body" when the error is not tied to a single field.
+func bodyErrorField(err error) string {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return typeErr.Field
+	}
+	return "body"
+}
+
+// requestFieldPath resolves a validator struct namespace on the request schema root into the
+// field's wire path and source. Top-level fields parsed from the path, query, headers, cookies
+// or form use their parse key; everything else is addressed by json names within the body.
+func requestFieldPath(c *fiber.Ctx, root reflect.Type, structNamespace string) ([]pathSegment, ParseSource) {
+	path := namespaceToPath(root, structNamespace)
+	field, ok := topLevelField(root, structNamespace)
+	if !ok || len(path) == 0 {
+		return path, Body
+	}
+	info := computeFieldInfo(field)
+	source := info.Source
+	if source == Auto {
+		source = resolveAutoSource(c, info.Key)
+	}
+	if source != Body {
+		path[0] = pathSegment{key: info.Key}
+	}
+	return path, source
+}
+
+// Errors returned by setFieldValue; their messages are shown to clients.
+var (
+	errNotInteger = errors.New("must be an integer")
+	errNotBoolean = errors.New("must be a boolean")
+	errNotNumber  = errors.New("must be a number")
+)
+
 // setFieldValue sets a struct field value with type conversion from string or interface{}.
 func setFieldValue(field reflect.Value, value interface{}) error {
 	switch field.Kind() {
@@ -351,7 +410,7 @@ func setFieldValue(field reflect.Value, value interface{}) error {
 			if intVal, err := parseInt(v); err == nil {
 				field.SetInt(int64(intVal))
 			} else {
-				return err
+				return errNotInteger
 			}
 		case int, int8, int16, int32, int64:
 			// Use reflect to safely convert any integer type to int64.
@@ -359,7 +418,7 @@ func setFieldValue(field reflect.Value, value interface{}) error {
 		case float64:
 			field.SetInt(int64(v))
 		default:
-			return fmt.Errorf("cannot convert %v to int", value)
+			return errNotInteger
 		}
 	case reflect.Bool:
 		switch v := value.(type) {
@@ -368,7 +427,7 @@ func setFieldValue(field reflect.Value, value interface{}) error {
 		case bool:
 			field.SetBool(v)
 		default:
-			return fmt.Errorf("cannot convert %v to bool", value)
+			return errNotBoolean
 		}
 	case reflect.Float32, reflect.Float64:
 		switch v := value.(type) {
@@ -376,7 +435,7 @@ func setFieldValue(field reflect.Value, value interface{}) error {
 			if floatVal, err := parseFloat(v); err == nil {
 				field.SetFloat(floatVal)
 			} else {
-				return err
+				return errNotNumber
 			}
 		case float64:
 			field.SetFloat(v)
@@ -384,7 +443,7 @@ func setFieldValue(field reflect.Value, value interface{}) error {
 			// Use reflect to safely convert any integer type to float64.
 			field.SetFloat(float64(reflect.ValueOf(v).Int()))
 		default:
-			return fmt.Errorf("cannot convert %v to float", value)
+			return errNotNumber
 		}
 	}
 	return nil
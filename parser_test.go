@@ -356,7 +356,7 @@ func TestParseEmbeddedPointerStructs_WithValidation(t *testing.T) {
 	_ = json.NewDecoder(resp.Body).Decode(&respBody)
 	assert.Equal(t, "Validation failed", respBody["error"])
 
-	// Check that details is an array of objects and contains fields for Email and FullName
+	// Check that details is an array of objects and reports email and fullName by their json names
 	details, ok := respBody["details"].([]interface{})
 	assert.True(t, ok, "details should be an array")
 	foundEmail := false
@@ -364,17 +364,17 @@ func TestParseEmbeddedPointerStructs_WithValidation(t *testing.T) {
 	for _, d := range details {
 		if m, ok := d.(map[string]interface{}); ok {
 			if f, ok := m["field"].(string); ok {
-				if strings.Contains(f, "Email") {
+				if f == "email" {
 					foundEmail = true
 				}
-				if strings.Contains(f, "FullName") {
+				if f == "fullName" {
 					foundFullName = true
 				}
 			}
 		}
 	}
-	assert.True(t, foundEmail, "details should mention Email")
-	assert.True(t, foundFullName, "details should mention FullName")
+	assert.True(t, foundEmail, "details should mention email")
+	assert.True(t, foundFullName, "details should mention fullName")
 
 	// Case 2: valid request, should pass validation
 	jsonBody = `{"email":"valid@example.com","fullName":"Valid User","isActive":true,"age":30}`
@@ -432,3 +432,103 @@ func TestResponseValidation_NoRequestSchema(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 }
+
+func TestValidationErrorDetails_WireNamesAndSources(t *testing.T) {
+	app := newTestApp()
+
+	type Address struct {
+		City string `json:"city" validate:"required"`
+	}
+	type Item struct {
+		SKU string `json:"sku" validate:"required"`
+	}
+	type OrderRequest struct {
+		OrgID   string  `parse:"path:orgId" validate:"len=3"`
+		Page    int     `json:"page" validate:"min=1"`
+		Token   string  `parse:"header:X-Token" validate:"required"`
+		Address Address `json:"address"`
+		Items   []Item  `json:"items" validate:"dive"`
+	}
+
+	app.Post("/orgs/:orgId/orders", func(c *fiber.Ctx, req *OrderRequest) (interface{}, error) {
+		return req, nil
+	}, autofiber.WithRequestSchema(OrderRequest{}))
+
+	body := `{"address":{},"items":[{"sku":"a"},{}]}`
+	req := httptest.NewRequest(http.MethodPost, "/orgs/ab/orders?page=0", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+	var payload autofiber.ValidationRequestError
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&payload))
+
+	byField := make(map[string]autofiber.FieldErrorDetail)
+	for _, d := range payload.Details {
+		byField[d.Field] = d
+	}
+	assert.Len(t, byField, 5)
+	assert.Equal(t, autofiber.FieldErrorDetail{Field: "orgId", Message: byField["orgId"].Message, Tag: "len", Source: "path"}, byField["orgId"])
+	assert.Equal(t, "query", byField["page"].Source)
+	assert.Empty(t, byField["page"].Pointer)
+	assert.Equal(t, "header", byField["X-Token"].Source)
+	assert.Equal(t, "body", byField["address.city"].Source)
+	assert.Equal(t, "/address/city", byField["address.city"].Pointer)
+	assert.Equal(t, "/items/1/sku", byField["items[1].sku"].Pointer)
+}
+
+func TestParseErrorDetails_Source(t *testing.T) {
+	app := newTestApp()
+
+	type Profile struct {
+		Age int `json:"age"`
+	}
+	type Req struct {
+		Limit   int     `parse:"query:limit"`
+		Page    int     `parse:"auto:page"`
+		Ratio   float64 `parse:"query:ratio"`
+		Profile Profile `json:"profile"`
+	}
+	app.Post("/p", func(c *fiber.Ctx, req *Req) (interface{}, error) {
+		return req, nil
+	}, autofiber.WithRequestSchema(Req{}))
+
+	decode := func(target, body string) autofiber.FieldErrorDetail {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		var payload autofiber.ValidationRequestError
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&payload))
+		if assert.Len(t, payload.Details, 1) {
+			return payload.Details[0]
+		}
+		return autofiber.FieldErrorDetail{}
+	}
+
+	detail := decode("/p?limit=abc", `{}`)
+	assert.Equal(t, "limit", detail.Field)
+	assert.Equal(t, "query", detail.Source)
+	assert.Equal(t, "must be an integer", detail.Message)
+	assert.Empty(t, detail.Pointer)
+
+	// Auto fields report where the value came from.
+	detail = decode("/p?page=first", `{}`)
+	assert.Equal(t, "page", detail.Field)
+	assert.Equal(t, "query", detail.Source)
+	assert.Equal(t, "must be an integer", detail.Message)
+
+	detail = decode("/p?ratio=half", `{}`)
+	assert.Equal(t, "must be a number", detail.Message)
+
+	detail = decode("/p", `{"profile":{"age":"old"}}`)
+	assert.Equal(t, "profile.age", detail.Field)
+	assert.Equal(t, "body", detail.Source)
+	assert.Equal(t, "/profile/age", detail.Pointer)
+
+	detail = decode("/p", `{"profile":`)
+	assert.Equal(t, "body", detail.Field)
+	assert.Empty(t, detail.Pointer)
+}
@@ -0,0 +1,116 @@
+// Package autofiber provides RFC 7807 problem details responses for AutoFiber-generated errors.
+package autofiber
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// ProblemContentType is the media type of problem details responses.
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails is an RFC 7807 problem details body. Errors carries the field-level
+// details of validation failures.
+type ProblemDetails struct {
+	Type      string             `json:"type"`
+	Title     string             `json:"title"`
+	Status    int                `json:"status"`
+	Detail    string             `json:"detail,omitempty"`
+	Instance  string             `json:"instance,omitempty"`
+	Code      string             `json:"code,omitempty"`
+	RequestID string             `json:"request_id,omitempty"`
+	Errors    []FieldErrorDetail `json:"errors,omitempty"`
+}
+
+// WithProblemDetails makes AutoFiber respond to the errors it generates (parse and validation
+// failures, response validation failures, missing Authorization headers and HTTPErrors) with
+// application/problem+json bodies, and documents that schema for each operation.
+// A handler set with WithErrorHandler still takes precedence for validation errors.
+//
+// Example:
+//
+//	app := autofiber.New(fiber.Config{}, autofiber.WithProblemDetails())
+//
+//	// HTTP 422
+//	// {"type":"about:blank","title":"Unprocessable Entity","status":422,
+//	//  "detail":"Validation failed","instance":"/users","errors":[...]}
+func WithProblemDetails() AutoFiberOption {
+	return func(af *AutoFiber) {
+		af.problemDetails = true
+		af.docsGenerator.problemDetails = true
+	}
+}
+
+// NewProblemDetails builds the problem details for err. Validation errors and HTTPErrors keep their
+// status and field details, fiber errors their code; anything else is reported as a 500.
+func NewProblemDetails(c *fiber.Ctx, err error) ProblemDetails {
+	status := fiber.StatusInternalServerError
+	detail := err.Error()
+	var code, requestID string
+	var details []FieldErrorDetail
+	switch e := err.(type) {
+	case *HTTPError:
+		status, detail, code, details, requestID = e.Status, e.Message, e.Code, e.Details, e.RequestID
+	case *ValidationRequestError:
+		status = fiber.StatusBadRequest
+		if e.Status != 0 {
+			status = e.Status
+		}
+		detail, code, details = e.Message, e.Code, e.Details
+	case *ValidationResponseError:
+		if e.Status != 0 {
+			status = e.Status
+		}
+		detail, code, details = e.Message, e.Code, e.Details
+	case *fiber.Error:
+		status, detail = e.Code, e.Message
+	}
+	return ProblemDetails{
+		Type:      "about:blank",
+		Title:     utils.StatusMessage(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  c.OriginalURL(),
+		Code:      code,
+		RequestID: requestID,
+		Errors:    details,
+	}
+}
+
+// writeProblem responds with the problem details for err.
+func writeProblem(c *fiber.Ctx, err error) error {
+	problem := NewProblemDetails(c, err)
+	return c.Status(problem.Status).JSON(problem, ProblemContentType)
+}
+
+// problemDetailsSchema is the OpenAPI schema of ProblemDetails.
+func problemDetailsSchema() OpenAPISchema {
+	return OpenAPISchema{
+		Type:     "object",
+		Required: []string{"type", "title", "status"},
+		Properties: map[string]OpenAPISchema{
+			"type":       {Type: "string", Format: "uri-reference", Example: "about:blank"},
+			"title":      {Type: "string"},
+			"status":     {Type: "integer"},
+			"detail":     {Type: "string"},
+			"instance":   {Type: "string", Format: "uri-reference"},
+			"code":       {Type: "string"},
+			"request_id": {Type: "string"},
+			"errors": {
+				Type: "array",
+				Items: &OpenAPISchema{
+					Type:     "object",
+					Required: []string{"field", "message"},
+					Properties: map[string]OpenAPISchema{
+						"field":   {Type: "string"},
+						"message": {Type: "string"},
+						"tag":     {Type: "string"},
+						"pointer": {Type: "string"},
+						"source":  {Type: "string"},
+						"code":    {Type: "string"},
+					},
+				},
+			},
+		},
+	}
+}
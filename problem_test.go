@@ -0,0 +1,112 @@
+package autofiber_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	autofiber "github.com/vuongtlt13/auto-fiber"
+)
+
+type problemReq struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type problemResp struct {
+	ID int `json:"id" validate:"required"`
+}
+
+func newProblemApp() *autofiber.AutoFiber {
+	app := autofiber.New(fiber.Config{}, autofiber.WithProblemDetails())
+	app.Post("/users", func(c *fiber.Ctx, req *problemReq) (interface{}, error) {
+		return problemResp{}, nil
+	}, autofiber.WithRequestSchema(problemReq{}), autofiber.WithResponseSchema(problemResp{}), autofiber.WithJwtAuth())
+	return app
+}
+
+func doProblem(t *testing.T, app *autofiber.AutoFiber, body string, auth bool) (*http.Response, autofiber.ProblemDetails) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/users?x=1", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if auth {
+		req.Header.Set("Authorization", "Bearer token")
+	}
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	var problem autofiber.ProblemDetails
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+	return resp, problem
+}
+
+func TestProblemDetails_Responses(t *testing.T) {
+	app := newProblemApp()
+
+	resp, problem := doProblem(t, app, `{"email":"nope"}`, true)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Equal(t, autofiber.ProblemContentType, resp.Header.Get("Content-Type"))
+	assert.Equal(t, "about:blank", problem.Type)
+	assert.Equal(t, "Unprocessable Entity", problem.Title)
+	assert.Equal(t, http.StatusUnprocessableEntity, problem.Status)
+	assert.Equal(t, "Validation failed", problem.Detail)
+	assert.Equal(t, "/users?x=1", problem.Instance)
+	if assert.Len(t, problem.Errors, 1) {
+		assert.Equal(t, "email", problem.Errors[0].Field)
+	}
+
+	resp, problem = doProblem(t, app, `{"email":`, true)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "Invalid request", problem.Detail)
+
+	resp, problem = doProblem(t, app, `{"email":"a@b.co"}`, false)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, autofiber.ProblemContentType, resp.Header.Get("Content-Type"))
+	assert.Equal(t, "Missing Authorization header", problem.Detail)
+
+	resp, problem = doProblem(t, app, `{"email":"a@b.co"}`, true)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, "Response validation failed", problem.Detail)
+	assert.Equal(t, "response.id", problem.Errors[0].Field)
+}
+
+func TestProblemDetails_ErrorHandlerTakesPrecedence(t *testing.T) {
+	app := autofiber.New(fiber.Config{}, autofiber.WithProblemDetails(),
+		autofiber.WithErrorHandler(func(c *fiber.Ctx, err error) error {
+			return c.Status(fiber.StatusTeapot).JSON(fiber.Map{"custom": err.Error()})
+		}),
+	)
+	app.Post("/users", func(c *fiber.Ctx, req *problemReq) (interface{}, error) {
+		return req, nil
+	}, autofiber.WithRequestSchema(problemReq{}))
+
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusTeapot, resp.StatusCode)
+}
+
+func TestProblemDetails_Docs(t *testing.T) {
+	spec := newProblemApp().GetOpenAPISpec()
+
+	assert.Contains(t, spec.Components.Schemas, "ProblemDetails")
+	responses := spec.Paths["/users"].Post.Responses
+	for _, code := range []string{"400", "401", "422", "500"} {
+		if assert.Contains(t, responses, code) {
+			media := responses[code].Content[autofiber.ProblemContentType]
+			assert.Equal(t, "#/components/schemas/ProblemDetails", media.Schema.Ref)
+		}
+	}
+
+	// Without the option the legacy error schema is kept.
+	plain := autofiber.New(fiber.Config{})
+	plain.Post("/users", func(c *fiber.Ctx, req *problemReq) (interface{}, error) {
+		return req, nil
+	}, autofiber.WithRequestSchema(problemReq{}))
+	plainSpec := plain.GetOpenAPISpec()
+	assert.NotContains(t, plainSpec.Components.Schemas, "ProblemDetails")
+	assert.Contains(t, plainSpec.Paths["/users"].Post.Responses["400"].Content, "application/json")
+}
@@ -0,0 +1,90 @@
+// Package autofiber provides panic recovery for route handlers with pluggable logging and request IDs.
+package autofiber
+
+import (
+	"runtime/debug"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// Logger receives the messages AutoFiber logs: recovered panics (Errorf) and reported response
+// validation failures (Warnf). The default logs through github.com/gofiber/fiber/v2/log.
+type Logger interface {
+	Errorf(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+}
+
+// WithLogger sets the logger AutoFiber writes its messages to.
+//
+// Example:
+//
+//	app := autofiber.New(fiber.Config{}, autofiber.WithLogger(zapLogger.Sugar()))
+func WithLogger(logger Logger) AutoFiberOption {
+	return func(af *AutoFiber) {
+		af.logger = logger
+	}
+}
+
+// WithPanicRecovery makes route handlers recover from panics, including the reflective call
+// failing because a handler's parameter type does not match its RequestSchema. The panic and its
+// stack are logged through the app's Logger, and the client receives a 500 HTTPError with code
+// CodeInternalError and the request ID, in the app's error format (WithErrorHandler or
+// WithProblemDetails when set). The request ID is taken from the requestid middleware or the
+// X-Request-ID header, or generated and echoed in the X-Request-ID response header.
+//
+// Example:
+//
+//	app := autofiber.New(fiber.Config{}, autofiber.WithPanicRecovery())
+func WithPanicRecovery() AutoFiberOption {
+	return func(af *AutoFiber) {
+		af.recoverPanics = true
+	}
+}
+
+// recoverHandler wraps next so panics are logged and turned into a 500 error response.
+func (af *AutoFiber) recoverHandler(next fiber.Handler, opts *RouteOptions) fiber.Handler {
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				requestID := requestIDFor(c)
+				af.logger.Errorf("autofiber: panic in %s %s (request %s): %v\n%s", c.Method(), c.Path(), requestID, r, debug.Stack())
+				err = af.handleRouteError(c, opts, &HTTPError{
+					Status:    fiber.StatusInternalServerError,
+					Message:   utils.StatusMessage(fiber.StatusInternalServerError),
+					Code:      CodeInternalError,
+					RequestID: requestID,
+				})
+			}
+		}()
+		return next(c)
+	}
+}
+
+// requestIDFor returns the ID of the current request: the one stored by fiber's requestid
+// middleware, else the X-Request-ID request header, else a new ID set on the response header.
+func requestIDFor(c *fiber.Ctx) string {
+	if id, ok := c.Locals("requestid").(string); ok && id != "" {
+		return id
+	}
+	if id := c.Get(fiber.HeaderXRequestID); id != "" {
+		return id
+	}
+	id := utils.UUIDv4()
+	c.Set(fiber.HeaderXRequestID, id)
+	return id
+}
+
+// fiberLogger is the default Logger, writing through fiber's log package.
+type fiberLogger struct{}
+
+// Errorf implements Logger.
+func (fiberLogger) Errorf(format string, args ...interface{}) {
+	log.Errorf(format, args...)
+}
+
+// Warnf implements Logger.
+func (fiberLogger) Warnf(format string, args ...interface{}) {
+	log.Warnf(format, args...)
+}
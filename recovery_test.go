@@ -0,0 +1,106 @@
+package autofiber_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	autofiber "github.com/vuongtlt13/auto-fiber"
+)
+
+// recordingLogger captures messages written by AutoFiber.
+type recordingLogger struct {
+	errors []string
+	warns  []string
+}
+
+func (l *recordingLogger) Errorf(format string, args ...interface{}) {
+	l.errors = append(l.errors, fmt.Sprintf(format, args...))
+}
+
+func (l *recordingLogger) Warnf(format string, args ...interface{}) {
+	l.warns = append(l.warns, fmt.Sprintf(format, args...))
+}
+
+func TestWithPanicRecovery(t *testing.T) {
+	logger := &recordingLogger{}
+	app := autofiber.New(fiber.Config{}, autofiber.WithPanicRecovery(), autofiber.WithLogger(logger))
+	app.Get("/boom", func(c *fiber.Ctx) (interface{}, error) {
+		panic("kaboom")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/boom", nil)
+	req.Header.Set(fiber.HeaderXRequestID, "req-123")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+	var body autofiber.HTTPError
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, autofiber.CodeInternalError, body.Code)
+	assert.Equal(t, "req-123", body.RequestID)
+	if assert.Len(t, logger.errors, 1) {
+		assert.Contains(t, logger.errors[0], "kaboom")
+		assert.Contains(t, logger.errors[0], "req-123")
+		assert.Contains(t, logger.errors[0], "goroutine")
+	}
+
+	// A request ID is generated and echoed when the client sends none.
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/boom", nil))
+	assert.NoError(t, err)
+	generated := resp.Header.Get(fiber.HeaderXRequestID)
+	assert.NotEmpty(t, generated)
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, generated, body.RequestID)
+}
+
+func TestWithPanicRecovery_MismatchedHandlerType(t *testing.T) {
+	type Req struct {
+		Name string `json:"name"`
+	}
+	type Other struct {
+		Name string `json:"name"`
+	}
+
+	app := autofiber.New(fiber.Config{}, autofiber.WithPanicRecovery(), autofiber.WithLogger(&recordingLogger{}), autofiber.WithProblemDetails())
+	app.Post("/mismatch", func(c *fiber.Ctx, req *Other) (interface{}, error) {
+		return req, nil
+	}, autofiber.WithRequestSchema(Req{}))
+
+	req := httptest.NewRequest(http.MethodPost, "/mismatch", strings.NewReader(`{"name":"x"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, autofiber.ProblemContentType, resp.Header.Get("Content-Type"))
+	var problem autofiber.ProblemDetails
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+	assert.Equal(t, autofiber.CodeInternalError, problem.Code)
+	assert.NotEmpty(t, problem.RequestID)
+}
+
+func TestWithLogger_ResponseValidationReports(t *testing.T) {
+	type Resp struct {
+		ID int `json:"id" validate:"required"`
+	}
+	logger := &recordingLogger{}
+	app := autofiber.New(fiber.Config{},
+		autofiber.WithLogger(logger),
+		autofiber.WithResponseValidation(autofiber.ReportResponseValidation()),
+	)
+	app.Get("/r", func(c *fiber.Ctx) (interface{}, error) {
+		return Resp{}, nil
+	}, autofiber.WithResponseSchema(Resp{}))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/r", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	if assert.Len(t, logger.warns, 1) {
+		assert.Contains(t, logger.warns[0], "response.id")
+	}
+}
@@ -1,10 +1,15 @@
 package autofiber
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"reflect"
+
+	"github.com/gofiber/fiber/v2"
+)
 
 // FileResponse is a special response type that can send a file to the client
 // instead of returning JSON. Any value that implements this interface will be
-// detected by AutoFiber and used to send the response directly.
+// detected by AutoFiber and used to send the response directly. DownloadFile, BytesFile and
+// StreamFile are the built-in implementations.
 type FileResponse interface {
 	// SendFileResponse is responsible for writing the file response to Fiber context.
 	SendFileResponse(c *fiber.Ctx) error
@@ -47,4 +52,54 @@ func (d DownloadFile) SendFileResponse(c *fiber.Ctx) error {
 	return c.Download(d.Path)
 }
 
+// Response lets a handler choose the status code and headers of a successful response.
+// Body is validated against the route's response schema and written as the response body;
+// a zero Status falls back to the route's status (WithStatus, default 200).
+//
+// Usage in handler:
+//
+//	func CreateUser(c *fiber.Ctx, req *CreateUserRequest) (interface{}, error) {
+//	    user := users.Create(req)
+//	    return autofiber.Response[*User]{
+//	        Status:  fiber.StatusCreated,
+//	        Headers: map[string]string{fiber.HeaderLocation: "/users/" + user.ID},
+//	        Body:    user,
+//	    }, nil
+//	}
+type Response[T any] struct {
+	Status  int
+	Headers map[string]string
+	Body    T
+	Meta    map[string]interface{} // Added to the envelope's meta when WithEnvelope is enabled
+}
+
+// parts implements responder.
+func (r Response[T]) parts() responseParts {
+	return responseParts{status: r.Status, headers: r.Headers, body: r.Body, meta: r.Meta}
+}
+
+// responseParts is a Response with its body type erased.
+type responseParts struct {
+	status  int
+	headers map[string]string
+	body    interface{}
+	meta    map[string]interface{}
+}
+
+// responder is implemented by Response of any body type.
+type responder interface {
+	parts() responseParts
+}
 
+// unwrapResponse splits a handler result into its parts. Results other than a Response (or
+// non-nil pointer to one) become the body with no status, headers or metadata.
+func unwrapResponse(data interface{}) responseParts {
+	r, ok := data.(responder)
+	if !ok {
+		return responseParts{body: data}
+	}
+	if v := reflect.ValueOf(data); v.Kind() == reflect.Ptr && v.IsNil() {
+		return responseParts{}
+	}
+	return r.parts()
+}
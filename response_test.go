@@ -0,0 +1,205 @@
+package autofiber_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autofiber "github.com/vuongtlt13/auto-fiber"
+)
+
+type createdUser struct {
+	ID   string `json:"id" validate:"required"`
+	Name string `json:"name"`
+}
+
+func TestWithStatus_SuccessStatus(t *testing.T) {
+	app := autofiber.New(fiber.Config{})
+	app.Post("/users", func(c *fiber.Ctx) (interface{}, error) {
+		return createdUser{ID: "1", Name: "Ada"}, nil
+	}, autofiber.WithResponseSchema(createdUser{}), autofiber.WithStatus(fiber.StatusCreated))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/users", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var body createdUser
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "Ada", body.Name)
+}
+
+func TestResponse_StatusHeadersAndBody(t *testing.T) {
+	app := autofiber.New(fiber.Config{})
+	app.Post("/users", func(c *fiber.Ctx) (interface{}, error) {
+		return autofiber.Response[createdUser]{
+			Status:  fiber.StatusAccepted,
+			Headers: map[string]string{fiber.HeaderLocation: "/users/1"},
+			Body:    createdUser{ID: "1", Name: "Ada"},
+		}, nil
+	}, autofiber.WithResponseSchema(createdUser{}), autofiber.WithStatus(fiber.StatusCreated))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/users", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, "/users/1", resp.Header.Get(fiber.HeaderLocation))
+
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, map[string]interface{}{"id": "1", "name": "Ada"}, body)
+}
+
+func TestResponse_ZeroStatusUsesRouteStatus(t *testing.T) {
+	app := autofiber.New(fiber.Config{})
+	app.Post("/users", func(c *fiber.Ctx) (interface{}, error) {
+		return &autofiber.Response[*createdUser]{Body: &createdUser{ID: "1"}}, nil
+	}, autofiber.WithStatus(fiber.StatusCreated))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/users", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestResponse_BodyIsValidated(t *testing.T) {
+	app := autofiber.New(fiber.Config{})
+	app.Post("/users", func(c *fiber.Ctx) (interface{}, error) {
+		return autofiber.Response[createdUser]{
+			Status:  fiber.StatusCreated,
+			Headers: map[string]string{fiber.HeaderLocation: "/users/"},
+			Body:    createdUser{Name: "missing id"},
+		}, nil
+	}, autofiber.WithResponseSchema(createdUser{}))
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/users", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get(fiber.HeaderLocation))
+}
+
+func TestWithStatus_Docs(t *testing.T) {
+	app := autofiber.New(fiber.Config{})
+	app.Post("/users", func(c *fiber.Ctx) (interface{}, error) {
+		return nil, nil
+	},
+		autofiber.WithResponseSchema(createdUser{}),
+		autofiber.WithStatus(fiber.StatusCreated),
+		autofiber.WithResponseHeader(fiber.HeaderLocation, "URL of the created user"),
+	)
+
+	spec := app.GetOpenAPISpec()
+	responses := spec.Paths["/users"].Post.Responses
+	assert.NotContains(t, responses, "200")
+	require.Contains(t, responses, "201")
+	created := responses["201"]
+	assert.Equal(t, "#/components/schemas/createdUser", created.Content["application/json"].Schema.Ref)
+	require.Contains(t, created.Headers, fiber.HeaderLocation)
+	assert.Equal(t, "URL of the created user", created.Headers[fiber.HeaderLocation].Description)
+	assert.Equal(t, "string", created.Headers[fiber.HeaderLocation].Schema.Type)
+
+	raw, err := json.Marshal(created)
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(string(raw), `"headers"`))
+}
+
+type queuedJob struct {
+	JobID string `json:"job_id" validate:"required"`
+}
+
+type notFoundBody struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+func declaredResponsesApp() *autofiber.AutoFiber {
+	app := newTestApp()
+	app.Post("/exports", func(c *fiber.Ctx) (interface{}, error) {
+		switch c.Query("case") {
+		case "queued":
+			return autofiber.Response[queuedJob]{Status: fiber.StatusAccepted, Body: queuedJob{JobID: "j1"}}, nil
+		case "bad-job":
+			return autofiber.Response[queuedJob]{Status: fiber.StatusAccepted}, nil
+		case "missing":
+			return autofiber.Response[notFoundBody]{Status: fiber.StatusNotFound, Body: notFoundBody{Reason: "no dataset"}}, nil
+		case "gone":
+			return autofiber.Response[map[string]string]{Status: fiber.StatusGone, Body: map[string]string{}}, nil
+		}
+		return createdUser{ID: "1", Name: "Ada"}, nil
+	},
+		autofiber.WithResponse(fiber.StatusOK, createdUser{}, "Export ready"),
+		autofiber.WithResponse(fiber.StatusAccepted, queuedJob{}, "Export queued"),
+		autofiber.WithResponse(fiber.StatusNotFound, notFoundBody{}, ""),
+		autofiber.WithResponse(fiber.StatusGone, nil, "Dataset removed"),
+		autofiber.WithResponseHeader(fiber.HeaderETag, "Export version"),
+	)
+	return app
+}
+
+func TestWithResponse_ValidatesAgainstStatusSchema(t *testing.T) {
+	app := declaredResponsesApp()
+
+	cases := []struct {
+		query  string
+		status int
+	}{
+		{"", http.StatusOK},
+		{"queued", http.StatusAccepted},
+		{"missing", http.StatusNotFound},
+		{"gone", http.StatusGone},                   // declared without schema: not validated
+		{"bad-job", http.StatusInternalServerError}, // job_id is required on 202
+	}
+	for _, tc := range cases {
+		resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/exports?case="+tc.query, nil))
+		require.NoError(t, err)
+		assert.Equal(t, tc.status, resp.StatusCode, tc.query)
+	}
+}
+
+func TestWithResponse_Docs(t *testing.T) {
+	spec := declaredResponsesApp().GetOpenAPISpec()
+	responses := spec.Paths["/exports"].Post.Responses
+
+	assert.Equal(t, "Export ready", responses["200"].Description)
+	assert.Equal(t, "#/components/schemas/createdUser", responses["200"].Content["application/json"].Schema.Ref)
+	assert.Contains(t, responses["200"].Headers, fiber.HeaderETag)
+	assert.Equal(t, "Export queued", responses["202"].Description)
+	assert.Equal(t, "#/components/schemas/queuedJob", responses["202"].Content["application/json"].Schema.Ref)
+	assert.Equal(t, "Not Found", responses["404"].Description)
+	assert.Equal(t, "#/components/schemas/notFoundBody", responses["404"].Content["application/json"].Schema.Ref)
+	assert.Equal(t, "Dataset removed", responses["410"].Description)
+	assert.Nil(t, responses["410"].Content)
+	assert.Contains(t, responses, "400", "generated error responses are kept")
+
+	for _, name := range []string{"createdUser", "queuedJob", "notFoundBody"} {
+		assert.Contains(t, spec.Components.Schemas, name)
+	}
+}
+
+func TestWithResponse_DocsReplaceDefaultSuccess(t *testing.T) {
+	app := autofiber.New(fiber.Config{})
+	handler := func(c *fiber.Ctx) (interface{}, error) { return nil, nil }
+	app.Post("/jobs", handler,
+		autofiber.WithResponse(fiber.StatusCreated, createdUser{}, "Job done"),
+		autofiber.WithResponse(fiber.StatusAccepted, queuedJob{}, "Job queued"),
+		autofiber.WithResponseHeader(fiber.HeaderLocation, "URL of the job"),
+	)
+	app.Put("/jobs", handler,
+		autofiber.WithStatus(fiber.StatusOK),
+		autofiber.WithResponse(fiber.StatusAccepted, queuedJob{}, "Job queued"),
+	)
+
+	spec := app.GetOpenAPISpec()
+	created := spec.Paths["/jobs"].Post.Responses
+	assert.NotContains(t, created, "200")
+	assert.Equal(t, "Job done", created["201"].Description)
+	assert.Contains(t, created["201"].Headers, fiber.HeaderLocation)
+	assert.Contains(t, created["202"].Headers, fiber.HeaderLocation)
+	assert.Contains(t, created, "400", "generated error responses are kept")
+
+	// An explicit WithStatus keeps its success response next to the declared ones.
+	updated := spec.Paths["/jobs"].Put.Responses
+	assert.Equal(t, "Successful operation", updated["200"].Description)
+	assert.Equal(t, "Job queued", updated["202"].Description)
+}
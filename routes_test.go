@@ -666,3 +666,46 @@ func TestAutoFiber_All_WithMiddleware(t *testing.T) {
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 	assert.True(t, called)
 }
+
+func TestAutoFiber_NoContentHandler(t *testing.T) {
+	type DeleteRequest struct {
+		ID string `parse:"path:id" validate:"required"`
+	}
+
+	app := autofiber.New(fiber.Config{})
+	deleted := ""
+	app.Delete("/users/:id", func(c *fiber.Ctx, req *DeleteRequest) error {
+		deleted = req.ID
+		return nil
+	}, autofiber.WithRequestSchema(DeleteRequest{}))
+	app.Post("/jobs/run", func(c *fiber.Ctx) error {
+		return nil
+	}, autofiber.WithStatus(fiber.StatusAccepted))
+	app.Delete("/locked", func(c *fiber.Ctx) error {
+		return autofiber.Conflict("resource is locked")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/users/7", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Empty(t, body)
+	assert.Equal(t, "7", deleted)
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodPost, "/jobs/run", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodDelete, "/locked", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	spec := app.GetOpenAPISpec()
+	responses := spec.Paths["/users/{id}"].Delete.Responses
+	assert.NotContains(t, responses, "200")
+	if assert.Contains(t, responses, "204") {
+		assert.Equal(t, "No Content", responses["204"].Description)
+		assert.Nil(t, responses["204"].Content)
+	}
+	assert.Contains(t, spec.Paths["/jobs/run"].Post.Responses, "202")
+}
@@ -0,0 +1,185 @@
+// Package autofiber provides Server-Sent Events and NDJSON streaming of handler results.
+package autofiber
+
+import (
+	"bufio"
+	"iter"
+	"reflect"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	// StreamContentTypeSSE is the media type of Server-Sent Events streams.
+	StreamContentTypeSSE = "text/event-stream"
+	// StreamContentTypeNDJSON is the media type of newline-delimited JSON streams.
+	StreamContentTypeNDJSON = "application/x-ndjson"
+)
+
+// isStreamType reports whether t is a receivable channel or an iterator func(yield func(T) bool),
+// the result types AutoFiber streams instead of encoding once.
+func isStreamType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Chan:
+		return t.ChanDir()&reflect.RecvDir != 0
+	case reflect.Func:
+		if t.NumIn() != 1 || t.NumOut() != 0 {
+			return false
+		}
+		yield := t.In(0)
+		return yield.Kind() == reflect.Func && yield.NumIn() == 1 && yield.NumOut() == 1 && yield.Out(0).Kind() == reflect.Bool
+	}
+	return false
+}
+
+// streamItemType returns the element type of stream type t.
+func streamItemType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Chan {
+		return t.Elem()
+	}
+	return t.In(0).In(0)
+}
+
+// handlerStreamItemType returns the item type when handler's declared result is a stream.
+func handlerStreamItemType(handler interface{}) (reflect.Type, bool) {
+	t := reflect.TypeOf(handler)
+	if t == nil || t.Kind() != reflect.Func || t.NumOut() != 2 || !isStreamType(t.Out(0)) {
+		return nil, false
+	}
+	return streamItemType(t.Out(0)), true
+}
+
+// streamItems returns the items of a channel or iterator result.
+func streamItems(data interface{}) (iter.Seq[reflect.Value], bool) {
+	v := reflect.ValueOf(data)
+	if !v.IsValid() || !isStreamType(v.Type()) || v.IsNil() {
+		return nil, false
+	}
+	return v.Seq(), true
+}
+
+// drainChannel keeps receiving from a channel result in the background until it is closed, so
+// that a producer blocked on a send after the stream ended early can finish.
+func drainChannel(data interface{}) {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Chan {
+		return
+	}
+	go func() {
+		for {
+			if _, ok := v.Recv(); !ok {
+				return
+			}
+		}
+	}()
+}
+
+// sendStream streams the items of data as Server-Sent Events, or as NDJSON when the Accept header
+// prefers application/x-ndjson. Each item is validated against the schema of the route's success
+// status according to the response validation mode: in enforce mode a failing item ends the
+// stream with an error event (or line) carrying the ValidationResponseError; otherwise the failure
+// goes to reportResponseError and the item is sent. The stream stops when items are exhausted
+// (channel closed) or the client is gone.
+//
+// Items are consumed after the handler returns, so the producer must not use the *fiber.Ctx. When
+// the stream ends, done (if set) cancels the handler's context; a channel the stream stopped
+// reading early is drained until its producer closes it.
+func (af *AutoFiber) sendStream(c *fiber.Ctx, opts *RouteOptions, data interface{}, done func()) error {
+	items, _ := streamItems(data)
+	format := c.Accepts(StreamContentTypeSSE, StreamContentTypeNDJSON)
+	if format == "" {
+		format = StreamContentTypeSSE
+	}
+	status := opts.successStatus()
+	schema := opts.responseSchemaFor(status)
+	mode := af.responseValidationMode(opts)
+	validate := schema != nil && mode.sampled()
+	encode := c.App().Config().JSONEncoder
+	method, path := c.Method(), utils.CopyString(c.Path())
+
+	// c must not be used once the handler returns, so failures reported while streaming get a
+	// detached copy of the request.
+	var reportCtx *fiber.Ctx
+	if validate && !mode.enforced() {
+		reportCtx = detachedCtx(c)
+	}
+
+	c.Set(fiber.HeaderContentType, format)
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	if format == StreamContentTypeSSE {
+		c.Set(fiber.HeaderConnection, "keep-alive")
+		c.Set("X-Accel-Buffering", "no")
+	}
+	c.Status(status)
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		completed := false
+		defer func() {
+			if done != nil {
+				done()
+			}
+			if !completed {
+				drainChannel(data)
+			}
+			if reportCtx != nil {
+				reportCtx.App().ReleaseCtx(reportCtx)
+			}
+		}()
+		for item := range items {
+			data := item.Interface()
+			if validate {
+				if verr := validateResponse(data, schema, af.validator); verr != nil {
+					if mode.enforced() {
+						_ = writeStreamItem(w, format, "error", verr, encode)
+						_ = w.Flush()
+						return
+					}
+					af.reportResponseError(reportCtx, verr)
+				}
+			}
+			if err := writeStreamItem(w, format, "", data, encode); err != nil {
+				af.logger.Errorf("autofiber: %s %s: encoding stream item: %v", method, path, err)
+				return
+			}
+			// A failed flush means the client has gone away.
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+		completed = true
+	})
+	return nil
+}
+
+// detachedCtx returns a *fiber.Ctx over a copy of c's request, Locals and response headers, usable
+// after the handler returns. Release it with App().ReleaseCtx.
+func detachedCtx(c *fiber.Ctx) *fiber.Ctx {
+	fctx := &fasthttp.RequestCtx{}
+	fctx.Init(c.Request(), c.Context().RemoteAddr(), nil)
+	c.Context().VisitUserValuesAll(func(key, value interface{}) {
+		fctx.SetUserValue(key, value)
+	})
+	c.Response().Header.CopyTo(&fctx.Response.Header)
+	return c.App().AcquireCtx(fctx)
+}
+
+// writeStreamItem writes data as one SSE event (named event when set) or one NDJSON line.
+func writeStreamItem(w *bufio.Writer, format, event string, data interface{}, encode utils.JSONMarshal) error {
+	payload, err := encode(data)
+	if err != nil {
+		return err
+	}
+	if format == StreamContentTypeNDJSON {
+		_, _ = w.Write(payload)
+		return w.WriteByte('\n')
+	}
+	if event != "" {
+		_, _ = w.WriteString("event: " + event + "\n")
+	}
+	_, _ = w.WriteString("data: ")
+	_, _ = w.Write(payload)
+	_, err = w.WriteString("\n\n")
+	return err
+}
@@ -0,0 +1,191 @@
+package autofiber_test
+
+import (
+	"context"
+	"io"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autofiber "github.com/vuongtlt13/auto-fiber"
+)
+
+type progressEvent struct {
+	Step    int    `json:"step" validate:"gte=1"`
+	Message string `json:"message"`
+}
+
+func progressChannel(c *fiber.Ctx) (<-chan progressEvent, error) {
+	ch := make(chan progressEvent, 2)
+	ch <- progressEvent{Step: 1, Message: "started"}
+	ch <- progressEvent{Step: 2, Message: "done"}
+	close(ch)
+	return ch, nil
+}
+
+func progressSeq(c *fiber.Ctx) (iter.Seq[progressEvent], error) {
+	return func(yield func(progressEvent) bool) {
+		for _, e := range []progressEvent{{Step: 1, Message: "a"}, {Step: 0, Message: "bad"}, {Step: 3, Message: "c"}} {
+			if !yield(e) {
+				return
+			}
+		}
+	}, nil
+}
+
+func readStream(t *testing.T, app *autofiber.AutoFiber, path, accept string) (*http.Response, string) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(body)
+}
+
+func TestStream_ChannelAsSSE(t *testing.T) {
+	app := autofiber.New(fiber.Config{})
+	app.Get("/progress", progressChannel)
+
+	resp, body := readStream(t, app, "/progress", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, autofiber.StreamContentTypeSSE, resp.Header.Get("Content-Type"))
+	assert.Equal(t, "data: {\"step\":1,\"message\":\"started\"}\n\ndata: {\"step\":2,\"message\":\"done\"}\n\n", body)
+}
+
+func TestStream_ChannelAsNDJSON(t *testing.T) {
+	app := autofiber.New(fiber.Config{})
+	app.Get("/progress", progressChannel)
+
+	resp, body := readStream(t, app, "/progress", autofiber.StreamContentTypeNDJSON)
+	assert.Equal(t, autofiber.StreamContentTypeNDJSON, resp.Header.Get("Content-Type"))
+	assert.Equal(t, "{\"step\":1,\"message\":\"started\"}\n{\"step\":2,\"message\":\"done\"}\n", body)
+}
+
+func TestStream_IteratorValidatesItems(t *testing.T) {
+	app := autofiber.New(fiber.Config{})
+	app.Get("/progress", progressSeq, autofiber.WithResponseSchema(progressEvent{}))
+
+	_, body := readStream(t, app, "/progress", "")
+	assert.Contains(t, body, "data: {\"step\":1,\"message\":\"a\"}\n\n")
+	assert.Contains(t, body, "event: error\ndata: {\"error\":\"Response validation failed\"")
+	assert.NotContains(t, body, "\"message\":\"c\"", "the stream ends at the invalid item")
+}
+
+func TestStream_ReportModeKeepsStreaming(t *testing.T) {
+	logger := &recordingLogger{}
+	app := autofiber.New(fiber.Config{},
+		autofiber.WithLogger(logger),
+		autofiber.WithResponseValidation(autofiber.ReportResponseValidation()),
+	)
+	app.Get("/progress", progressSeq, autofiber.WithResponseSchema(progressEvent{}))
+
+	_, body := readStream(t, app, "/progress", autofiber.StreamContentTypeNDJSON)
+	assert.Equal(t, "{\"step\":1,\"message\":\"a\"}\n{\"step\":0,\"message\":\"bad\"}\n{\"step\":3,\"message\":\"c\"}\n", body)
+	assert.NotEmpty(t, logger.warns)
+}
+
+func TestStream_ReportModeUsesReporter(t *testing.T) {
+	type report struct {
+		path   string
+		locals interface{}
+		fields []string
+	}
+	reports := make(chan report, 3)
+	app := autofiber.New(fiber.Config{},
+		autofiber.WithResponseValidation(autofiber.ReportResponseValidation()),
+		autofiber.WithResponseValidationReporter(func(c *fiber.Ctx, err *autofiber.ValidationResponseError) {
+			r := report{path: c.Path(), locals: c.Locals("tenant")}
+			for _, detail := range err.Details {
+				r.fields = append(r.fields, detail.Field)
+			}
+			reports <- r
+		}),
+	)
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("tenant", "acme")
+		return c.Next()
+	})
+	app.Get("/progress", progressSeq, autofiber.WithResponseSchema(progressEvent{}))
+
+	_, body := readStream(t, app, "/progress", autofiber.StreamContentTypeNDJSON)
+	assert.Contains(t, body, "\"message\":\"c\"")
+	require.Len(t, reports, 1)
+	r := <-reports
+	assert.Equal(t, "/progress", r.path)
+	assert.Equal(t, "acme", r.locals, "the reporter sees the request's Locals")
+	assert.Equal(t, []string{"response.step"}, r.fields)
+}
+
+func TestStream_EarlyEndReleasesProducer(t *testing.T) {
+	stopped := make(chan struct{})
+	app := autofiber.New(fiber.Config{})
+	app.Get("/progress", func(ctx context.Context) (<-chan progressEvent, error) {
+		ch := make(chan progressEvent)
+		go func() {
+			defer close(stopped)
+			defer close(ch)
+			for step := 1; ; step++ {
+				select {
+				case ch <- progressEvent{Step: step % 2}: // every second item is invalid
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		return ch, nil
+	}, autofiber.WithResponseSchema(progressEvent{}))
+
+	_, body := readStream(t, app, "/progress", "")
+	assert.Contains(t, body, "event: error")
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("the producer was not told to stop after the stream ended")
+	}
+}
+
+func TestStream_EarlyEndDrainsChannel(t *testing.T) {
+	stopped := make(chan struct{})
+	app := autofiber.New(fiber.Config{})
+	app.Get("/progress", func(c *fiber.Ctx) (<-chan progressEvent, error) {
+		ch := make(chan progressEvent)
+		go func() {
+			defer close(stopped)
+			defer close(ch)
+			// Ignores cancellation: only draining unblocks the sends after the invalid item.
+			for _, step := range []int{1, 0, 2, 3} {
+				ch <- progressEvent{Step: step}
+			}
+		}()
+		return ch, nil
+	}, autofiber.WithResponseSchema(progressEvent{}))
+
+	_, body := readStream(t, app, "/progress", "")
+	assert.Contains(t, body, "event: error")
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("the producer stayed blocked after the stream ended")
+	}
+}
+
+func TestStream_Docs(t *testing.T) {
+	app := autofiber.New(fiber.Config{})
+	app.Get("/progress", progressChannel)
+
+	spec := app.GetOpenAPISpec()
+	ok := spec.Paths["/progress"].Get.Responses["200"]
+	require.Contains(t, ok.Content, autofiber.StreamContentTypeSSE)
+	require.Contains(t, ok.Content, autofiber.StreamContentTypeNDJSON)
+	assert.Equal(t, "#/components/schemas/progressEvent", ok.Content[autofiber.StreamContentTypeSSE].Schema.Ref)
+	assert.Contains(t, spec.Components.Schemas, "progressEvent")
+}
@@ -0,0 +1,222 @@
+// Package autofiber provides localized validation messages selected from the request's Accept-Language header.
+package autofiber
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	entranslations "github.com/go-playground/validator/v10/translations/en"
+	"github.com/gofiber/fiber/v2"
+)
+
+// defaultLocale is used when the Accept-Language header matches no registered locale.
+const defaultLocale = "en"
+
+// WithLocale registers an additional locale for validation messages. register installs the
+// locale's messages on the instance validator and is typically one of go-playground's
+// RegisterDefaultTranslations functions; it may be nil when messages are added later with
+// RegisterTranslation. The locale is selected per request from the Accept-Language header.
+// Panics if register fails, so misconfiguration surfaces at startup.
+//
+// Example:
+//
+//	import (
+//	    "github.com/go-playground/locales/vi"
+//	    vitranslations "github.com/go-playground/validator/v10/translations/vi"
+//	)
+//
+//	app := autofiber.New(fiber.Config{},
+//	    autofiber.WithLocale(vi.New(), vitranslations.RegisterDefaultTranslations),
+//	)
+func WithLocale(locale locales.Translator, register func(*validator.Validate, ut.Translator) error) AutoFiberOption {
+	return func(af *AutoFiber) {
+		if err := af.translations.uni.AddTranslator(locale, true); err != nil {
+			panic(fmt.Sprintf("autofiber: adding locale %q: %v", locale.Locale(), err))
+		}
+		trans, _ := af.translations.uni.GetTranslator(locale.Locale())
+		if register != nil {
+			if err := register(af.validator, trans); err != nil {
+				panic(fmt.Sprintf("autofiber: registering %q translations: %v", locale.Locale(), err))
+			}
+		}
+		af.translations.add(trans)
+	}
+}
+
+// RegisterTranslation sets the message for a validation tag in a registered locale.
+// In text, {0} is replaced by the field name and {1} by the tag parameter.
+//
+// Example:
+//
+//	app.RegisterTranslation("en", "strong_password", "{0} must contain upper, lower and digit characters")
+func (af *AutoFiber) RegisterTranslation(locale, tag, text string) error {
+	trans, found := af.translations.uni.GetTranslator(locale)
+	if !found {
+		return fmt.Errorf("locale %q is not registered", locale)
+	}
+	return af.validator.RegisterTranslation(tag, trans,
+		func(t ut.Translator) error {
+			return t.Add(tag, text, true)
+		},
+		func(t ut.Translator, fe validator.FieldError) string {
+			msg, err := t.T(fe.Tag(), fe.Field(), fe.Param())
+			if err != nil {
+				return fe.Error()
+			}
+			return msg
+		},
+	)
+}
+
+// translations holds the universal translator and the locales offered for negotiation.
+type translations struct {
+	uni     *ut.UniversalTranslator
+	offers  []string                 // Accept-Language offers, default locale first (e.g. "en", "pt-BR")
+	byOffer map[string]ut.Translator // offer → translator
+}
+
+// newTranslations creates the translation registry with friendly English messages
+// registered on v as the default locale, and makes v name fields by wireFieldName.
+func newTranslations(v *validator.Validate) *translations {
+	v.RegisterTagNameFunc(wireFieldName)
+	english := en.New()
+	t := &translations{
+		uni:     ut.New(english, english),
+		byOffer: make(map[string]ut.Translator),
+	}
+	trans, _ := t.uni.GetTranslator(defaultLocale)
+	if err := entranslations.RegisterDefaultTranslations(v, trans); err != nil {
+		panic(fmt.Sprintf("autofiber: registering default translations: %v", err))
+	}
+	t.add(trans)
+	return t
+}
+
+// add offers trans for Accept-Language negotiation.
+func (t *translations) add(trans ut.Translator) {
+	offer := strings.ReplaceAll(trans.Locale(), "_", "-")
+	if _, exists := t.byOffer[offer]; !exists {
+		t.offers = append(t.offers, offer)
+	}
+	t.byOffer[offer] = trans
+}
+
+// defaultTranslator returns the translator for the default locale.
+func (t *translations) defaultTranslator() ut.Translator {
+	return t.byOffer[t.offers[0]]
+}
+
+// forRequest picks the translator best matching the request's Accept-Language header. Each
+// language range, by decreasing quality, matches a locale with the same tag, then the locale of
+// its base language ("vi-VN" → "vi"), then another region of that language ("pt-PT" → "pt-BR").
+// Tags compare case-insensitively; "*" and unmatched ranges select the default locale.
+func (t *translations) forRequest(c *fiber.Ctx) ut.Translator {
+	for _, lang := range acceptedLanguages(c.Get(fiber.HeaderAcceptLanguage)) {
+		if lang == "*" {
+			break
+		}
+		base, _, _ := strings.Cut(lang, "-")
+		var baseMatch, regionMatch string
+		for _, offer := range t.offers {
+			offerBase, _, _ := strings.Cut(offer, "-")
+			switch {
+			case strings.EqualFold(offer, lang):
+				return t.byOffer[offer]
+			case strings.EqualFold(offer, base) && baseMatch == "":
+				baseMatch = offer
+			case strings.EqualFold(offerBase, base) && regionMatch == "":
+				regionMatch = offer
+			}
+		}
+		if baseMatch != "" {
+			return t.byOffer[baseMatch]
+		}
+		if regionMatch != "" {
+			return t.byOffer[regionMatch]
+		}
+	}
+	return t.defaultTranslator()
+}
+
+// acceptedLanguages returns the language ranges of an Accept-Language header by decreasing
+// quality, dropping those with q=0.
+func acceptedLanguages(header string) []string {
+	type weighted struct {
+		lang    string
+		quality float64
+	}
+	var ranges []weighted
+	for _, part := range strings.Split(header, ",") {
+		lang, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang = strings.ReplaceAll(strings.TrimSpace(lang), "_", "-")
+		if lang == "" {
+			continue
+		}
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(q), 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality > 0 {
+			ranges = append(ranges, weighted{lang: lang, quality: quality})
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].quality > ranges[j].quality })
+	langs := make([]string, len(ranges))
+	for i, r := range ranges {
+		langs[i] = r.lang
+	}
+	return langs
+}
+
+// wireFieldName names struct fields in validation messages the way clients send them: by parse
+// key for fields parsed from the path, query, headers, cookies or form, by json name otherwise.
+// newTranslations registers it on the validator, so {0} in messages matches FieldErrorDetail.Field.
+func wireFieldName(field reflect.StructField) string {
+	return computeFieldInfo(field).Key
+}
+
+// message renders fe in the given locale, falling back to the default locale and then to
+// a generic sentence for tags without any registered message.
+func (t *translations) message(trans ut.Translator, fe validator.FieldError) string {
+	raw := fe.Error()
+	if msg := fe.Translate(trans); msg != raw {
+		return msg
+	}
+	if def := t.defaultTranslator(); def != trans {
+		if msg := fe.Translate(def); msg != raw {
+			return msg
+		}
+	}
+	return genericMessage(fe.Field(), fe.Tag(), fe.Param())
+}
+
+// text renders the message registered for tag in the given locale, falling back to the default
+// locale and then to a generic sentence. It serves tags checked outside the validator.
+func (t *translations) text(trans ut.Translator, tag, field, param string) string {
+	if msg, err := trans.T(tag, field, param); err == nil {
+		return msg
+	}
+	if msg, err := t.defaultTranslator().T(tag, field, param); err == nil {
+		return msg
+	}
+	return genericMessage(field, tag, param)
+}
+
+// genericMessage describes a failed tag that has no registered message.
+func genericMessage(field, tag, param string) string {
+	if param != "" {
+		return fmt.Sprintf("%s failed the '%s=%s' validation", field, tag, param)
+	}
+	return fmt.Sprintf("%s failed the '%s' validation", field, tag)
+}
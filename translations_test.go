@@ -0,0 +1,147 @@
+package autofiber_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/locales/vi"
+	"github.com/go-playground/validator/v10"
+	vitranslations "github.com/go-playground/validator/v10/translations/vi"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	autofiber "github.com/vuongtlt13/auto-fiber"
+)
+
+type translatedReq struct {
+	Email string `json:"email" validate:"required,email"`
+	Code  string `json:"code" validate:"is_code"`
+}
+
+// captureDetails posts body to /t and returns the validation details from the response.
+func captureDetails(t *testing.T, app *autofiber.AutoFiber, body, acceptLanguage string) []autofiber.FieldErrorDetail {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/t", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if acceptLanguage != "" {
+		req.Header.Set("Accept-Language", acceptLanguage)
+	}
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+	var payload autofiber.ValidationRequestError
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&payload))
+	return payload.Details
+}
+
+func newTranslatedApp(options ...autofiber.AutoFiberOption) *autofiber.AutoFiber {
+	options = append(options,
+		autofiber.WithErrorHandler(func(c *fiber.Ctx, err error) error {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(err)
+		}),
+		autofiber.WithValidatorSetup(func(v *validator.Validate) {
+			_ = v.RegisterValidation("is_code", func(fl validator.FieldLevel) bool {
+				return strings.HasPrefix(fl.Field().String(), "C-")
+			})
+		}),
+	)
+	app := autofiber.New(fiber.Config{}, options...)
+	app.Post("/t", func(c *fiber.Ctx, req *translatedReq) (interface{}, error) {
+		return req, nil
+	}, autofiber.WithRequestSchema(translatedReq{}))
+	return app
+}
+
+func TestValidationMessages_DefaultEnglish(t *testing.T) {
+	app := newTranslatedApp()
+
+	details := captureDetails(t, app, `{"code":"C-1"}`, "")
+	if assert.Len(t, details, 1) {
+		assert.Equal(t, "email is a required field", details[0].Message)
+		assert.Equal(t, "required", details[0].Tag)
+	}
+
+	// Tags without a registered message get a generic sentence instead of the raw validator error.
+	details = captureDetails(t, app, `{"email":"a@b.co","code":"X"}`, "")
+	if assert.Len(t, details, 1) {
+		assert.Equal(t, "code failed the 'is_code' validation", details[0].Message)
+	}
+}
+
+func TestValidationMessages_AcceptLanguage(t *testing.T) {
+	app := newTranslatedApp(autofiber.WithLocale(vi.New(), vitranslations.RegisterDefaultTranslations))
+
+	english := captureDetails(t, app, `{"code":"C-1"}`, "en-US,en;q=0.9")
+	vietnamese := captureDetails(t, app, `{"code":"C-1"}`, "vi-VN,vi;q=0.9,en;q=0.5")
+	if assert.Len(t, english, 1) && assert.Len(t, vietnamese, 1) {
+		assert.Equal(t, "email is a required field", english[0].Message)
+		assert.NotEqual(t, english[0].Message, vietnamese[0].Message)
+		assert.Contains(t, vietnamese[0].Message, "email")
+	}
+
+	// Regional and differently cased tags fall back to their base language.
+	for _, header := range []string{"vi-VN", "VI", "de;q=0.9, vi-VN;q=0.5", "en;q=0, vi_VN"} {
+		details := captureDetails(t, app, `{"code":"C-1"}`, header)
+		if assert.Len(t, details, 1, header) {
+			assert.Equal(t, vietnamese[0].Message, details[0].Message, header)
+		}
+	}
+
+	// Unknown languages fall back to English.
+	details := captureDetails(t, app, `{"code":"C-1"}`, "de-DE")
+	if assert.Len(t, details, 1) {
+		assert.Equal(t, "email is a required field", details[0].Message)
+	}
+}
+
+func TestValidationMessages_WireNames(t *testing.T) {
+	type Req struct {
+		Page    int    `parse:"query:page_size" validate:"min=1"`
+		Contact string `json:"contact_email" validate:"email"`
+	}
+	app := newTranslatedApp()
+	app.Post("/wire", func(c *fiber.Ctx, req *Req) (interface{}, error) {
+		return req, nil
+	}, autofiber.WithRequestSchema(Req{}))
+
+	req := httptest.NewRequest(http.MethodPost, "/wire?page_size=0", strings.NewReader(`{"contact_email":"x"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	var payload autofiber.ValidationRequestError
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&payload))
+	messages := map[string]string{}
+	for _, detail := range payload.Details {
+		messages[detail.Field] = detail.Message
+	}
+	assert.Equal(t, map[string]string{
+		"page_size":     "page_size must be 1 or greater",
+		"contact_email": "contact_email must be a valid email address",
+	}, messages)
+}
+
+func TestRegisterTranslation(t *testing.T) {
+	app := newTranslatedApp(autofiber.WithLocale(vi.New(), nil))
+
+	assert.NoError(t, app.RegisterTranslation("en", "is_code", "{0} must start with C-"))
+	assert.NoError(t, app.RegisterTranslation("vi", "is_code", "{0} phải bắt đầu bằng C-"))
+	assert.Error(t, app.RegisterTranslation("fr", "is_code", "{0} doit commencer par C-"))
+
+	details := captureDetails(t, app, `{"email":"a@b.co","code":"X"}`, "")
+	if assert.Len(t, details, 1) {
+		assert.Equal(t, "code must start with C-", details[0].Message)
+	}
+	details = captureDetails(t, app, `{"email":"a@b.co","code":"X"}`, "vi")
+	if assert.Len(t, details, 1) {
+		assert.Equal(t, "code phải bắt đầu bằng C-", details[0].Message)
+	}
+
+	// A locale registered without default messages falls back to English per tag.
+	details = captureDetails(t, app, `{"code":"C-1"}`, "vi")
+	if assert.Len(t, details, 1) {
+		assert.Equal(t, "email is a required field", details[0].Message)
+	}
+}
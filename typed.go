@@ -0,0 +1,117 @@
+// Package autofiber provides generic, type-checked route registration for AutoFiber apps and groups.
+package autofiber
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TypedHandler is a handler whose request and response types are checked at compile time.
+type TypedHandler[Req, Res any] func(c *fiber.Ctx, req *Req) (*Res, error)
+
+// RouteRegistrar is implemented by *AutoFiber and *AutoFiberGroup, the targets of the generic
+// route helpers Get, Post, Put, Patch and Delete.
+type RouteRegistrar interface {
+	addTypedRoute(method, path string, handler interface{}, invoke invokeFunc, options []RouteOption) fiber.Router
+}
+
+// Get registers a GET route whose request and response schemas are inferred from the type
+// parameters. See Post.
+func Get[Req, Res any](r RouteRegistrar, path string, handler TypedHandler[Req, Res], options ...RouteOption) fiber.Router {
+	return addTyped(r, fiber.MethodGet, path, handler, options)
+}
+
+// Post registers a POST route whose request and response schemas are inferred from the type
+// parameters, so they are declared once and checked by the compiler. The handler is called
+// directly rather than through reflection. Use struct{} as Req for routes without request input.
+// An explicit WithResponseSchema takes precedence over the inferred response schema; an explicit
+// WithRequestSchema must be of type Req, or registration panics.
+//
+// Example:
+//
+//	autofiber.Post(app, "/users", func(c *fiber.Ctx, req *CreateUserRequest) (*User, error) {
+//	    return users.Create(c.UserContext(), req)
+//	}, autofiber.WithStatus(fiber.StatusCreated))
+//
+//	api := app.Group("/api")
+//	autofiber.Get(api, "/users/:id", getUser)
+func Post[Req, Res any](r RouteRegistrar, path string, handler TypedHandler[Req, Res], options ...RouteOption) fiber.Router {
+	return addTyped(r, fiber.MethodPost, path, handler, options)
+}
+
+// Put registers a PUT route whose request and response schemas are inferred from the type
+// parameters. See Post.
+func Put[Req, Res any](r RouteRegistrar, path string, handler TypedHandler[Req, Res], options ...RouteOption) fiber.Router {
+	return addTyped(r, fiber.MethodPut, path, handler, options)
+}
+
+// Patch registers a PATCH route whose request and response schemas are inferred from the type
+// parameters. See Post.
+func Patch[Req, Res any](r RouteRegistrar, path string, handler TypedHandler[Req, Res], options ...RouteOption) fiber.Router {
+	return addTyped(r, fiber.MethodPatch, path, handler, options)
+}
+
+// Delete registers a DELETE route whose request and response schemas are inferred from the type
+// parameters. See Post.
+func Delete[Req, Res any](r RouteRegistrar, path string, handler TypedHandler[Req, Res], options ...RouteOption) fiber.Router {
+	return addTyped(r, fiber.MethodDelete, path, handler, options)
+}
+
+// addTyped prepends the schemas inferred from Req and Res to options and registers handler on r.
+func addTyped[Req, Res any](r RouteRegistrar, method, path string, handler TypedHandler[Req, Res], options []RouteOption) fiber.Router {
+	reqType := reflect.TypeOf((*Req)(nil)).Elem()
+	if reqType.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("autofiber: request type of %s %s must be a struct, got %s", method, path, reqType))
+	}
+
+	// The handler receives the parsed request as *Req, so an explicit request schema must be a Req.
+	if explicit := applyOptions(options).RequestSchema; explicit != nil && derefType(reflect.TypeOf(explicit)) != reqType {
+		panic(fmt.Sprintf("autofiber: request schema of %s %s must be %s, got %T", method, path, reqType, explicit))
+	}
+
+	var inferred []RouteOption
+	if reqType.NumField() > 0 {
+		inferred = append(inferred, WithRequestSchema(*new(Req)))
+	}
+	if resType := responseBodyType(reflect.TypeOf((*Res)(nil)).Elem()); resType.Kind() == reflect.Struct {
+		inferred = append(inferred, WithResponseSchema(reflect.New(resType).Elem().Interface()))
+	}
+
+	invoke := func(c *fiber.Ctx, req interface{}) (interface{}, error) {
+		typed, _ := req.(*Req)
+		if typed == nil {
+			typed = new(Req)
+		}
+		return handler(c, typed)
+	}
+	return r.addTypedRoute(method, path, handler, invoke, append(inferred, options...))
+}
+
+// addTypedRoute implements RouteRegistrar.
+func (af *AutoFiber) addTypedRoute(method, path string, handler interface{}, invoke invokeFunc, options []RouteOption) fiber.Router {
+	opts := applyOptions(options)
+	autoHandler := af.withRecovery(af.adapt(handler, opts, invoke), opts)
+	af.docsGenerator.AddRoute(path, method, handler, opts)
+	return af.App.Add(method, path, append(opts.Middleware, autoHandler)...)
+}
+
+// addTypedRoute implements RouteRegistrar.
+func (ag *AutoFiberGroup) addTypedRoute(method, path string, handler interface{}, invoke invokeFunc, options []RouteOption) fiber.Router {
+	opts := applyOptions(options)
+	ag.mergeOpts(opts)
+	autoHandler := ag.app.withRecovery(ag.app.adapt(handler, opts, invoke), opts)
+	ag.app.docsGenerator.AddRoute(ag.Prefix+path, method, handler, opts)
+	return ag.Group.Add(method, path, append(opts.Middleware, autoHandler)...)
+}
+
+// responseBodyType returns the Body type for a Response type parameter and t itself otherwise,
+// dereferencing pointers.
+func responseBodyType(t reflect.Type) reflect.Type {
+	if t.Implements(reflect.TypeOf((*responder)(nil)).Elem()) {
+		body, _ := t.FieldByName("Body")
+		t = body.Type
+	}
+	return derefType(t)
+}
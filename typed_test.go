@@ -0,0 +1,159 @@
+package autofiber_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autofiber "github.com/vuongtlt13/auto-fiber"
+)
+
+type typedCreateRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type typedGetRequest struct {
+	ID string `parse:"path:id" validate:"required"`
+}
+
+type typedUser struct {
+	ID   string `json:"id" validate:"required"`
+	Name string `json:"name"`
+}
+
+func TestTyped_Post(t *testing.T) {
+	app := autofiber.New(fiber.Config{}, autofiber.WithProblemDetails())
+	autofiber.Post(app, "/users", func(c *fiber.Ctx, req *typedCreateRequest) (*typedUser, error) {
+		return &typedUser{ID: "1", Name: req.Name}, nil
+	}, autofiber.WithStatus(fiber.StatusCreated))
+
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"Ada"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var body typedUser
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, typedUser{ID: "1", Name: "Ada"}, body)
+
+	// The inferred request schema is validated.
+	req = httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func TestTyped_ResponseValidatedAgainstInferredSchema(t *testing.T) {
+	app := autofiber.New(fiber.Config{})
+	autofiber.Post(app, "/users", func(c *fiber.Ctx, req *typedCreateRequest) (*typedUser, error) {
+		return &typedUser{Name: req.Name}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"Ada"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+}
+
+func TestTyped_Group(t *testing.T) {
+	app := autofiber.New(fiber.Config{})
+	api := app.Group("/api")
+	autofiber.Get(api, "/users/:id", func(c *fiber.Ctx, req *typedGetRequest) (*typedUser, error) {
+		return &typedUser{ID: req.ID}, nil
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/users/42", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body typedUser
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "42", body.ID)
+
+	spec := app.GetOpenAPISpec()
+	op := spec.Paths["/api/users/{id}"].Get
+	require.NotNil(t, op)
+	assert.Equal(t, "#/components/schemas/typedUser", op.Responses["200"].Content["application/json"].Schema.Ref)
+	require.Len(t, op.Parameters, 1)
+	assert.Equal(t, "id", op.Parameters[0].Name)
+}
+
+func TestTyped_EmptyRequest(t *testing.T) {
+	app := autofiber.New(fiber.Config{})
+	autofiber.Delete(app, "/cache", func(c *fiber.Ctx, _ *struct{}) (*typedUser, error) {
+		return &typedUser{ID: "cleared"}, nil
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/cache", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	spec := app.GetOpenAPISpec()
+	op := spec.Paths["/cache"].Delete
+	require.NotNil(t, op)
+	assert.Nil(t, op.RequestBody)
+}
+
+func TestTyped_ResponseBodySchema(t *testing.T) {
+	app := autofiber.New(fiber.Config{})
+	autofiber.Put(app, "/users", func(c *fiber.Ctx, req *typedCreateRequest) (*autofiber.Response[typedUser], error) {
+		return &autofiber.Response[typedUser]{Status: fiber.StatusAccepted, Body: typedUser{ID: "1", Name: req.Name}}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodPut, "/users", strings.NewReader(`{"name":"Ada"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	spec := app.GetOpenAPISpec()
+	assert.Equal(t, "#/components/schemas/typedUser", spec.Paths["/users"].Put.Responses["200"].Content["application/json"].Schema.Ref)
+}
+
+func TestTyped_ExplicitSchemaOverridesInferred(t *testing.T) {
+	type userSummary struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	app := autofiber.New(fiber.Config{})
+	autofiber.Patch(app, "/users", func(c *fiber.Ctx, req *typedCreateRequest) (*typedUser, error) {
+		return &typedUser{Name: req.Name}, nil
+	}, autofiber.WithResponseSchema(userSummary{}))
+
+	spec := app.GetOpenAPISpec()
+	assert.Equal(t, "#/components/schemas/userSummary", spec.Paths["/users"].Patch.Responses["200"].Content["application/json"].Schema.Ref)
+}
+
+func TestTyped_NonStructRequestPanics(t *testing.T) {
+	app := autofiber.New(fiber.Config{})
+	assert.Panics(t, func() {
+		autofiber.Post(app, "/ids", func(c *fiber.Ctx, req *[]string) (*typedUser, error) {
+			return nil, nil
+		})
+	})
+}
+
+func TestTyped_MismatchedRequestSchemaPanics(t *testing.T) {
+	type otherRequest struct {
+		Title string `json:"title"`
+	}
+	app := autofiber.New(fiber.Config{})
+	assert.Panics(t, func() {
+		autofiber.Post(app, "/users", func(c *fiber.Ctx, req *typedCreateRequest) (*typedUser, error) {
+			return &typedUser{Name: req.Name}, nil
+		}, autofiber.WithRequestSchema(otherRequest{}))
+	})
+	assert.NotPanics(t, func() {
+		autofiber.Post(app, "/users", func(c *fiber.Ctx, req *typedCreateRequest) (*typedUser, error) {
+			return &typedUser{Name: req.Name}, nil
+		}, autofiber.WithRequestSchema(&typedCreateRequest{}))
+	})
+}
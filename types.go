@@ -15,12 +15,53 @@ type RouteOption func(*RouteOptions)
 
 // RouteOptions contains configuration for a route, such as schemas, middleware, and metadata.
 type RouteOptions struct {
-	RequestSchema  interface{}     // Struct for request parsing and validation
-	ResponseSchema interface{}     // Struct for response validation and documentation
-	Middleware     []fiber.Handler // Middleware handlers for the route
-	Description    string          // Description for API documentation
-	Tags           []string        // Tags for API documentation
-	RequireJWTAuth bool            // Require HTTP Bearer (JWT) auth for this route (OpenAPI security)
+	RequestSchema      interface{}                   // Struct for request parsing and validation
+	ResponseSchema     interface{}                   // Struct for response validation and documentation
+	Middleware         []fiber.Handler               // Middleware handlers for the route
+	Description        string                        // Description for API documentation
+	Tags               []string                      // Tags for API documentation
+	RequireJWTAuth     bool                          // Require HTTP Bearer (JWT) auth for this route (OpenAPI security)
+	ResponseValidation *ResponseValidationMode       // Overrides the app-level response validation mode when set
+	Errors             []int                         // Error statuses the route can produce; replaces the default 400/500 in docs
+	ErrorHandler       func(*fiber.Ctx, error) error // Handles this route's parse, validation and handler errors instead of the app-level handling
+	Status             int                           // Success status code; 200 when zero
+	ResponseHeaders    map[string]string             // Documented success response headers: name → description
+	Timeout            time.Duration                 // Deadline of the context passed to context-first handlers; none when zero
+	Produces           []string                      // Media types the response may be encoded in; all registered encoders when empty
+	Fields             bool                          // Project JSON responses onto the fields selected with ?fields=
+	Expand             []string                      // Expansions clients may request with ?expand=
+	Responses          map[int]RouteResponse         // Responses declared with WithResponse, by status code
+}
+
+// RouteResponse is a response a route declares for a status code with WithResponse.
+type RouteResponse struct {
+	Schema      interface{} // Body schema, used for validation and documentation; nil for no body
+	Description string      // Description for API documentation
+}
+
+// successStatus returns the route's success status code, defaulting to 200.
+func (o *RouteOptions) successStatus() int {
+	if o.Status == 0 {
+		return fiber.StatusOK
+	}
+	return o.Status
+}
+
+// responseSchemaFor returns the schema a body sent with status is validated against: the one
+// declared with WithResponse for status, or the response schema otherwise.
+func (o *RouteOptions) responseSchemaFor(status int) interface{} {
+	if resp, ok := o.Responses[status]; ok {
+		return resp.Schema
+	}
+	return o.ResponseSchema
+}
+
+// noContentStatus returns the success status code of an error-only handler, defaulting to 204.
+func (o *RouteOptions) noContentStatus() int {
+	if o.Status == 0 {
+		return fiber.StatusNoContent
+	}
+	return o.Status
 }
 
 // ParseSource defines where a field should be parsed from (e.g., body, query, path, header, etc.).
@@ -57,6 +98,7 @@ type ParseError struct {
 	Field   string // Name of the field
 	Source  string // Source of the field (e.g., body, query)
 	Message string // Error message
+	Code    string // Machine-readable code: CodeMissingField, CodeInvalidValue or CodeInvalidBody
 }
 
 // Error returns the error message for a ParseError.
@@ -64,6 +106,23 @@ func (e *ParseError) Error() string {
 	return e.Field + " (" + e.Source + "): " + e.Message
 }
 
+// RequestValidator is implemented by request schemas with rules that are awkward to express as
+// validate tags, such as "end date after start date" or "either email or phone". Validate runs
+// after tag validation; return FieldErrors to report field-scoped failures. Its failures are
+// merged with tag failures into ValidationRequestError.Details.
+//
+// Example:
+//
+//	func (r *BookingRequest) Validate(c *fiber.Ctx) error {
+//	    if !r.End.After(r.Start) {
+//	        return autofiber.FieldErrors{{Field: "end", Message: "end must be after start", Tag: "after"}}
+//	    }
+//	    return nil
+//	}
+type RequestValidator interface {
+	Validate(c *fiber.Ctx) error
+}
+
 // HandlerFunc is a Fiber handler function.
 type HandlerFunc func(*fiber.Ctx) error
 
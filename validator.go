@@ -2,89 +2,518 @@
 package autofiber
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand/v2"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
-	"github.com/gofiber/fiber/v2"
 )
 
+// responseValidationKind enumerates the response validation modes.
+type responseValidationKind int
+
+const (
+	responseValidationEnforce responseValidationKind = iota
+	responseValidationReport
+	responseValidationSample
+)
+
+// ResponseValidationMode controls what happens when a response fails validation against
+// its WithResponseSchema schema. The zero value enforces validation.
+type ResponseValidationMode struct {
+	kind responseValidationKind
+	rate float64
+}
+
+// EnforceResponseValidation rejects invalid responses with a ValidationResponseError (a 500 to the client).
+// This is the default mode.
+func EnforceResponseValidation() ResponseValidationMode {
+	return ResponseValidationMode{kind: responseValidationEnforce}
+}
+
+// ReportResponseValidation validates every response but sends invalid ones anyway,
+// passing the failure to the reporter set with WithResponseValidationReporter (or the log).
+func ReportResponseValidation() ResponseValidationMode {
+	return ResponseValidationMode{kind: responseValidationReport}
+}
+
+// SampleResponseValidation validates a random fraction of responses (rate between 0 and 1)
+// and reports failures like ReportResponseValidation, keeping the overhead low in production.
+func SampleResponseValidation(rate float64) ResponseValidationMode {
+	if rate < 0 {
+		rate = 0
+	} else if rate > 1 {
+		rate = 1
+	}
+	return ResponseValidationMode{kind: responseValidationSample, rate: rate}
+}
+
+// sampled reports whether the current response should be validated.
+func (m ResponseValidationMode) sampled() bool {
+	if m.kind != responseValidationSample {
+		return true
+	}
+	return m.rate > 0 && rand.Float64() < m.rate
+}
+
+// enforced reports whether validation failures should replace the response with an error.
+func (m ResponseValidationMode) enforced() bool {
+	return m.kind == responseValidationEnforce
+}
+
+// pathSegment is one step of a JSON path: an object key or an array index.
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// appendKey returns path extended with an object key, without aliasing the caller's slice.
+func appendKey(path []pathSegment, key string) []pathSegment {
+	out := make([]pathSegment, len(path), len(path)+1)
+	copy(out, path)
+	return append(out, pathSegment{key: key})
+}
+
+// appendIndex returns path extended with an array index, without aliasing the caller's slice.
+func appendIndex(path []pathSegment, index int) []pathSegment {
+	out := make([]pathSegment, len(path), len(path)+1)
+	copy(out, path)
+	return append(out, pathSegment{index: index, isIndex: true})
+}
+
+// formatFieldPath renders a path in dotted form, e.g. "items[1].name".
+func formatFieldPath(path []pathSegment) string {
+	var b strings.Builder
+	for i, seg := range path {
+		if seg.isIndex {
+			b.WriteString("[" + strconv.Itoa(seg.index) + "]")
+			continue
+		}
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(seg.key)
+	}
+	return b.String()
+}
+
+// responseValidationErrors collects every failing field found while walking a response.
+type responseValidationErrors []FieldErrorDetail
+
+// Error joins the individual failures into a single message.
+func (e responseValidationErrors) Error() string {
+	parts := make([]string, len(e))
+	for i, d := range e {
+		parts[i] = d.Field + ": " + d.Message
+	}
+	return strings.Join(parts, "; ")
+}
+
+// responseErrorDetails returns the per-field details carried by a validateResponseData error.
+func responseErrorDetails(err error) []FieldErrorDetail {
+	var errs responseValidationErrors
+	if errors.As(err, &errs) {
+		return errs
+	}
+	return []FieldErrorDetail{{
+		Field:   "response",
+		Message: err.Error(),
+	}}
+}
+
 // validateResponseData validates response data against the provided schema using the given validator.
-// It supports validating structs, pointers to structs, slices of structs, and maps. If the schema is nil, validation is skipped.
-// Returns an error if validation fails, or nil if the data is valid.
+// It walks arbitrary nesting: structs (including generic envelopes), pointers, slices, arrays and maps,
+// converting map payloads into the schema type and checking primitive payloads against primitive schemas.
+// If the schema is nil, validation is skipped.
+// Returns a responseValidationErrors listing every failing field with its JSON path, or nil if the data is valid.
 func validateResponseData(data interface{}, schema interface{}, validator *validator.Validate) error {
 	// If schema is nil, skip validation
 	if schema == nil {
 		return nil
 	}
 
-	// For simple types, validate directly
-	if reflect.TypeOf(data) == reflect.TypeOf(schema) {
-		return validator.Struct(data)
+	// A collection schema (e.g. []User{}) describes its elements.
+	schemaType := derefType(reflect.TypeOf(schema))
+	for (schemaType.Kind() == reflect.Slice || schemaType.Kind() == reflect.Array) && schemaType.Elem().Kind() != reflect.Uint8 {
+		schemaType = derefType(schemaType.Elem())
 	}
 
-	// For complex types, try to validate as struct
-	dataValue := reflect.ValueOf(data)
-	if dataValue.Kind() == reflect.Ptr {
-		dataValue = dataValue.Elem()
+	w := &responseWalker{validator: validator, schemaType: schemaType}
+	if err := w.walk(reflect.ValueOf(data), nil); err != nil {
+		return err
+	}
+	if len(w.errs) > 0 {
+		return w.errs
 	}
+	return nil
+}
 
-	if dataValue.Kind() == reflect.Struct {
-		return validator.Struct(data)
+// responseWalker accumulates field errors while traversing a response value.
+type responseWalker struct {
+	validator  *validator.Validate
+	schemaType reflect.Type
+	errs       responseValidationErrors
+}
+
+// walk validates v, found at path, against the walker's schema type.
+// It returns an error only for payloads that cannot be validated at all (e.g. unsupported maps).
+func (w *responseWalker) walk(v reflect.Value, path []pathSegment) error {
+	v, ok := unwrapValue(v)
+	if !ok {
+		return nil
 	}
 
-	// For maps, convert to struct before validation
-	if dataValue.Kind() == reflect.Map {
-		// Create a new instance of the schema
-		schemaType := reflect.TypeOf(schema)
-		if schemaType.Kind() == reflect.Ptr {
-			schemaType = schemaType.Elem()
+	switch v.Kind() {
+	case reflect.Struct:
+		if jsonKind(v.Type()) != jsonKind(w.schemaType) {
+			w.typeMismatch(path, v)
+			return nil
 		}
+		if v.Type() != reflect.TypeOf(time.Time{}) {
+			w.validateStruct(v, path)
+		}
+		return nil
 
-		// Convert any map type to map[string]interface{}
-		var mapData map[string]interface{}
-
-		// Try different map type assertions
-		if m, ok := data.(map[string]interface{}); ok {
-			mapData = m
-		} else if fm, ok := data.(fiber.Map); ok {
-			mapData = map[string]interface{}(fm)
-		} else {
-			// For any other map type, try to convert it
-			dataType := dataValue.Type()
-			if dataType.Key().Kind() == reflect.String {
-				// Convert map to map[string]interface{}
-				mapData = make(map[string]interface{})
-				iter := dataValue.MapRange()
-				for iter.Next() {
-					key := iter.Key().String()
-					value := iter.Value().Interface()
-					mapData[key] = value
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map type: %s", v.Type())
+		}
+		if jsonKind(w.schemaType) != "object" {
+			w.typeMismatch(path, v)
+			return nil
+		}
+		if w.schemaType.Kind() != reflect.Struct {
+			// Map schemas carry no field rules to check.
+			return nil
+		}
+		// A map of concrete structs is a keyed collection; anything else is an object payload.
+		if elem := derefType(v.Type().Elem()); elem.Kind() == reflect.Struct && elem != reflect.TypeOf(time.Time{}) {
+			iter := v.MapRange()
+			for iter.Next() {
+				if err := w.walk(iter.Value(), appendKey(path, iter.Key().String())); err != nil {
+					return err
 				}
 			}
+			return nil
+		}
+		decoded, err := decodeIntoSchema(v.Interface(), w.schemaType)
+		if err != nil {
+			return fmt.Errorf("failed to convert map to struct: %w", err)
 		}
+		w.validateStruct(decoded, path)
+		return nil
 
-		if mapData != nil {
-			structData := reflect.New(schemaType).Interface()
-			if err := ParseFromMap(mapData, structData); err != nil {
-				return fmt.Errorf("failed to convert map to struct: %w", err)
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 && w.schemaType.Kind() != reflect.Uint8 {
+			// []byte is encoded as a base64 string.
+			w.checkPrimitive(reflect.ValueOf(""), path)
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := w.walk(v.Index(i), appendIndex(path, i)); err != nil {
+				return err
 			}
-			return validator.Struct(structData)
 		}
+		return nil
+	}
+
+	w.checkPrimitive(v, path)
+	return nil
+}
+
+// validateStruct runs the validator on a struct value and then walks the slices and maps
+// it contains, which the validator only inspects when tagged with `dive`.
+func (w *responseWalker) validateStruct(v reflect.Value, path []pathSegment) {
+	w.recordStructErrors(v, path)
+	w.walkContainers(v, path)
+}
+
+// recordStructErrors validates v and records each failing field with its JSON path.
+func (w *responseWalker) recordStructErrors(v reflect.Value, path []pathSegment) {
+	var target interface{}
+	if v.CanAddr() {
+		target = v.Addr().Interface()
+	} else {
+		target = v.Interface()
+	}
 
-		// If we can't convert the map, return an error
-		return fmt.Errorf("unsupported map type: %T", data)
+	err := w.validator.Struct(target)
+	if err == nil {
+		return
 	}
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		w.errs = append(w.errs, FieldErrorDetail{Field: fieldPathOrRoot(path), Message: err.Error()})
+		return
+	}
+	for _, verr := range validationErrs {
+		fieldPath := append(append([]pathSegment{}, path...), namespaceToPath(v.Type(), verr.StructNamespace())...)
+		w.errs = append(w.errs, FieldErrorDetail{
+			Field:   fieldPathOrRoot(fieldPath),
+			Message: verr.Error(),
+			Tag:     verr.Tag(),
+		})
+	}
+}
+
+// walkContainers descends into the struct's fields looking for slices and maps whose elements
+// the validator did not check. Nested structs are already validated by the validator itself.
+func (w *responseWalker) walkContainers(v reflect.Value, path []pathSegment) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		validateTag := field.Tag.Get("validate")
+		if validateTag == "-" || field.Tag.Get("json") == "-" {
+			continue
+		}
+
+		fv, ok := unwrapValue(v.Field(i))
+		if !ok {
+			continue
+		}
 
-	// For slices, validate each element
-	if dataValue.Kind() == reflect.Slice {
-		for i := 0; i < dataValue.Len(); i++ {
-			element := dataValue.Index(i).Interface()
-			if err := validateResponseData(element, schema, validator); err != nil {
-				return fmt.Errorf("element %d: %w", i, err)
+		fieldPath := path
+		if !field.Anonymous {
+			fieldPath = appendKey(path, getFieldKey(field))
+		}
+
+		switch fv.Kind() {
+		case reflect.Struct:
+			if fv.Type() != reflect.TypeOf(time.Time{}) {
+				w.walkContainers(fv, fieldPath)
 			}
+		case reflect.Slice, reflect.Array, reflect.Map:
+			w.walkElements(fv, fieldPath, !strings.Contains(validateTag, "dive"))
 		}
 	}
+}
 
-	return nil
+// walkElements visits the elements of a nested slice, array or map. Struct elements are
+// validated unless the validator already did so through a `dive` tag.
+func (w *responseWalker) walkElements(v reflect.Value, path []pathSegment, validateStructs bool) {
+	visit := func(elem reflect.Value, elemPath []pathSegment) {
+		elem, ok := unwrapValue(elem)
+		if !ok {
+			return
+		}
+		switch elem.Kind() {
+		case reflect.Struct:
+			if elem.Type() == reflect.TypeOf(time.Time{}) {
+				return
+			}
+			if validateStructs {
+				w.recordStructErrors(elem, elemPath)
+			}
+			w.walkContainers(elem, elemPath)
+		case reflect.Slice, reflect.Array, reflect.Map:
+			w.walkElements(elem, elemPath, validateStructs)
+		}
+	}
+
+	if v.Kind() == reflect.Map {
+		iter := v.MapRange()
+		for iter.Next() {
+			visit(iter.Value(), appendKey(path, fmt.Sprint(iter.Key().Interface())))
+		}
+		return
+	}
+	for i := 0; i < v.Len(); i++ {
+		visit(v.Index(i), appendIndex(path, i))
+	}
+}
+
+// checkPrimitive records a type error when a primitive value does not match a primitive schema.
+func (w *responseWalker) checkPrimitive(v reflect.Value, path []pathSegment) {
+	if w.schemaType.Kind() == reflect.Interface {
+		return
+	}
+	if jsonKind(v.Type()) != jsonKind(w.schemaType) {
+		w.typeMismatch(path, v)
+	}
 }
+
+// typeMismatch records that the value at path has the wrong JSON type for the schema.
+func (w *responseWalker) typeMismatch(path []pathSegment, v reflect.Value) {
+	w.errs = append(w.errs, FieldErrorDetail{
+		Field:   fieldPathOrRoot(path),
+		Message: fmt.Sprintf("expected %s, got %s", jsonKind(w.schemaType), jsonKind(v.Type())),
+		Tag:     "type",
+	})
+}
+
+// fieldPathOrRoot formats path rooted at "response" (e.g. "response.items[1].name").
+func fieldPathOrRoot(path []pathSegment) string {
+	return formatFieldPath(append([]pathSegment{{key: "response"}}, path...))
+}
+
+// jsonKind names the JSON type a Go type is encoded as.
+func jsonKind(t reflect.Type) string {
+	t = derefType(t)
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return "string"
+		}
+		return "object"
+	case reflect.Map:
+		return "object"
+	}
+	return t.Kind().String()
+}
+
+// unwrapValue dereferences pointers and interfaces, reporting false for nil or invalid values.
+func unwrapValue(v reflect.Value) (reflect.Value, bool) {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, v.IsValid()
+}
+
+// derefType strips pointer indirections from t.
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// decodeIntoSchema converts a map payload into a new value of schemaType by round-tripping
+// it through JSON, which is exactly what the client will receive. Nested maps and slices of
+// maps therefore land in the matching nested schema fields.
+func decodeIntoSchema(data interface{}, schemaType reflect.Type) (reflect.Value, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	target := reflect.New(schemaType)
+	if err := json.Unmarshal(raw, target.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+	return target.Elem(), nil
+}
+
+// namespaceToPath converts a validator struct namespace (e.g. "Resp.Items[1].Name") into a JSON
+// path relative to root, using json names and dropping embedded struct names.
+// Unresolvable segments are kept verbatim.
+func namespaceToPath(root reflect.Type, structNamespace string) []pathSegment {
+	var path []pathSegment
+	t := root
+	for _, part := range strings.Split(trimNamespaceRoot(root, structNamespace), ".") {
+		name, subscripts := splitNamespacePart(part)
+
+		var field reflect.StructField
+		found := false
+		if t != nil && t.Kind() == reflect.Struct {
+			field, found = t.FieldByName(name)
+		}
+		if !found {
+			path = append(path, pathSegment{key: name})
+			t = nil
+		} else {
+			if !field.Anonymous {
+				path = append(path, pathSegment{key: getFieldKey(field)})
+			}
+			t = derefType(field.Type)
+		}
+
+		for _, sub := range subscripts {
+			if n, err := strconv.Atoi(sub); err == nil && t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+				path = append(path, pathSegment{index: n, isIndex: true})
+			} else {
+				path = append(path, pathSegment{key: sub})
+			}
+			if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map) {
+				t = derefType(t.Elem())
+			} else {
+				t = nil
+			}
+		}
+	}
+	return path
+}
+
+// trimNamespaceRoot strips the root struct name from a validator struct namespace.
+func trimNamespaceRoot(root reflect.Type, structNamespace string) string {
+	ns := strings.TrimPrefix(structNamespace, root.Name()+".")
+	if ns == structNamespace {
+		// Fall back to dropping the first segment when the root name is unexpected.
+		if idx := strings.Index(ns, "."); idx != -1 {
+			ns = ns[idx+1:]
+		}
+	}
+	return ns
+}
+
+// splitNamespacePart splits a namespace segment such as "Items[1]" into its field name and subscripts.
+func splitNamespacePart(part string) (string, []string) {
+	idx := strings.Index(part, "[")
+	if idx == -1 {
+		return part, nil
+	}
+	var subscripts []string
+	for _, s := range strings.Split(part[idx+1:], "[") {
+		subscripts = append(subscripts, strings.TrimSuffix(s, "]"))
+	}
+	return part[:idx], subscripts
+}
+
+// topLevelField returns the first non-embedded field of root named by structNamespace,
+// i.e. the request field a nested validation error belongs to.
+func topLevelField(root reflect.Type, structNamespace string) (reflect.StructField, bool) {
+	t := root
+	for _, part := range strings.Split(trimNamespaceRoot(root, structNamespace), ".") {
+		name, _ := splitNamespacePart(part)
+		if t == nil || t.Kind() != reflect.Struct {
+			return reflect.StructField{}, false
+		}
+		field, found := t.FieldByName(name)
+		if !found {
+			return reflect.StructField{}, false
+		}
+		if !field.Anonymous {
+			return field, true
+		}
+		t = derefType(field.Type)
+	}
+	return reflect.StructField{}, false
+}
+
+// jsonPointer renders a path as an RFC 6901 JSON pointer, e.g. "/items/1/name".
+func jsonPointer(path []pathSegment) string {
+	var b strings.Builder
+	for _, seg := range path {
+		b.WriteByte('/')
+		if seg.isIndex {
+			b.WriteString(strconv.Itoa(seg.index))
+			continue
+		}
+		b.WriteString(pointerEscaper.Replace(seg.key))
+	}
+	return b.String()
+}
+
+// pointerEscaper escapes JSON pointer reference tokens.
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")